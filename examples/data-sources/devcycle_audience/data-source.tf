@@ -0,0 +1,4 @@
+data "devcycle_audience" "internal" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key        = "internal-employees"
+}
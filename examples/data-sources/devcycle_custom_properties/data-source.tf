@@ -0,0 +1,3 @@
+data "devcycle_custom_properties" "all" {
+  project_id = "622112634cabe0e9fbaf974d"
+}
@@ -0,0 +1,9 @@
+data "devcycle_evaluated_features" "test" {
+  user = {
+    id = "acceptancetesting"
+  }
+}
+
+output "variation" {
+  value = data.devcycle_evaluated_features.test.features["acceptance-testing"].variation_key
+}
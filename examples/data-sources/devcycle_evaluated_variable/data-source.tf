@@ -0,0 +1,8 @@
+data "devcycle_evaluated_variable" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key        = "acceptance-testing-boolean"
+  user = {
+    id = "acceptancetesting"
+  }
+  default_value = "false"
+}
@@ -1,7 +1,11 @@
 data "devcycle_evaluated_variable_string" "test" {
   id = "acceptance-testing-string"
   user = {
-    id = "acceptancetesting"
+    id      = "acceptancetesting"
+    country = "CA"
+    custom_data = jsonencode({
+      beta = true
+    })
   }
   default_value = "string"
 }
\ No newline at end of file
@@ -0,0 +1,10 @@
+data "devcycle_evaluated_variables" "test" {
+  user = {
+    id = "acceptancetesting"
+  }
+  key_prefix = "acceptance-testing"
+}
+
+output "string_value" {
+  value = jsondecode(data.devcycle_evaluated_variables.test.variables["acceptance-testing-string"].value)
+}
@@ -0,0 +1,2 @@
+# Audiences can be imported using the project and audience keys
+terraform import devcycle_audience.internal project-key/audience-key
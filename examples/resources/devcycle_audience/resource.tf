@@ -0,0 +1,58 @@
+resource "devcycle_audience" "internal" {
+  project_id  = "622112634cabe0e9fbaf974d"
+  key         = "internal-employees"
+  name        = "Internal Employees"
+  description = "Users with a DevCycle email address"
+  operator    = "or"
+  filters = [
+    {
+      type       = "user"
+      sub_type   = "email"
+      comparator = "contain"
+      values     = ["@devcycle.com"]
+    },
+    {
+      type     = "op"
+      operator = "and"
+      filters = [
+        {
+          type          = "user"
+          sub_type      = "customData"
+          data_key      = "employee"
+          data_key_type = "Boolean"
+          comparator    = "="
+          values        = ["true"]
+        },
+        {
+          type       = "user"
+          sub_type   = "country"
+          comparator = "="
+          values     = ["CA"]
+        }
+      ]
+    }
+  ]
+}
+
+# Audiences can be reused in feature targeting with an audienceMatch filter.
+resource "devcycle_feature_targeting" "internal" {
+  project_id     = "622112634cabe0e9fbaf974d"
+  feature_id     = "terraform-acceptance-testing"
+  environment_id = "development"
+  enabled        = true
+  targets = [
+    {
+      audience = {
+        operator = "and"
+        filters = [
+          {
+            type       = "audienceMatch"
+            comparator = "="
+            audiences  = [devcycle_audience.internal.id]
+          }
+        ]
+      }
+      serve = "variation-on"
+    }
+  ]
+}
@@ -0,0 +1,2 @@
+# Custom properties can be imported using the project and custom property keys
+terraform import devcycle_custom_property.plan project-key/custom-property-key
@@ -0,0 +1,21 @@
+resource "devcycle_custom_property" "plan" {
+  project_id   = "622112634cabe0e9fbaf974d"
+  key          = "subscription-plan"
+  property_key = "subscriptionPlan"
+  name         = "Subscription Plan"
+  type         = "String"
+  schema = {
+    required = false
+    enum_values = [
+      {
+        label = "Free"
+        value = "free"
+      },
+      {
+        label = "Enterprise"
+        value = "enterprise"
+      }
+    ]
+    allow_additional_values = false
+  }
+}
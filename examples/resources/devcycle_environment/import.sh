@@ -0,0 +1,2 @@
+# Environments can be imported using the project and environment keys
+terraform import devcycle_environment.test project-key/environment-key
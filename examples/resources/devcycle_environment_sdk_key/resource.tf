@@ -0,0 +1,15 @@
+resource "devcycle_environment_sdk_key" "server" {
+  project_id            = "project_id"
+  environment_id        = "development"
+  type                  = "server"
+  invalidate_on_destroy = true
+
+  # Change to generate a new key and invalidate the previous one
+  keepers = {
+    rotation = "2023-01"
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
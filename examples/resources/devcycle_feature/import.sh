@@ -0,0 +1,2 @@
+# Features can be imported using the project and feature keys
+terraform import devcycle_feature.test project-key/feature-key
@@ -0,0 +1,2 @@
+# Feature targeting can be imported using the project, feature and environment keys
+terraform import devcycle_feature_targeting.test project-key/feature-key/environment-key
@@ -0,0 +1,44 @@
+resource "devcycle_feature_targeting" "test" {
+  project_id     = "622112634cabe0e9fbaf974d"
+  feature_id     = "terraform-acceptance-testing"
+  environment_id = "development"
+  enabled        = true
+  targets = [
+    {
+      name = "Internal users"
+      audience = {
+        operator = "and"
+        filters = [
+          {
+            type       = "user"
+            sub_type   = "email"
+            comparator = "contain"
+            values     = ["@devcycle.com"]
+          }
+        ]
+      }
+      serve = "variation-on"
+    },
+    {
+      name = "Everyone else"
+      audience = {
+        operator = "and"
+        filters = [
+          {
+            type = "all"
+          }
+        ]
+      }
+      distribution = [
+        {
+          variation  = "variation-on"
+          percentage = 0.5
+        },
+        {
+          variation  = "variation-off"
+          percentage = 0.5
+        }
+      ]
+    }
+  ]
+}
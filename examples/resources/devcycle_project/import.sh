@@ -0,0 +1,2 @@
+# Projects can be imported using the project key
+terraform import devcycle_project.test project-key
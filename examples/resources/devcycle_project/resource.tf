@@ -2,4 +2,14 @@ resource "devcycle_project" "test" {
   name        = "TerraformAccTest"
   key         = "project-key"
   description = "Terraform acceptance testing"
-}
\ No newline at end of file
+
+  settings = {
+    color = "#5a8f3c"
+    edge_db = {
+      enabled = true
+    }
+    feature_approval_workflow = {
+      enabled = true
+    }
+  }
+}
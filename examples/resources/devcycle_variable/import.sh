@@ -0,0 +1,2 @@
+# Variables can be imported using the project and variable keys
+terraform import devcycle_variable.test project-key/variable-key
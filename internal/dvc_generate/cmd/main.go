@@ -0,0 +1,105 @@
+// Command cmd writes Terraform configuration and import blocks for an existing
+// DevCycle project, to bring it under management with this provider.
+//
+// It authenticates like the provider, with DEVCYCLE_CLIENT_ID and
+// DEVCYCLE_CLIENT_SECRET or DEVCYCLE_ACCESS_TOKEN, and honors DEVCYCLE_API_URL
+// and DEVCYCLE_AUTH_URL.
+//
+//	go run ./internal/dvc_generate/cmd -project my-project -out ./devcycle
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/devcyclehq/terraform-provider-devcycle/internal/dvc_generate"
+	"github.com/devcyclehq/terraform-provider-devcycle/internal/dvc_http"
+	"github.com/devcyclehq/terraform-provider-devcycle/internal/dvc_oauth"
+)
+
+func main() {
+	project := flag.String("project", "", "Key or ID of the project to generate configuration for")
+	out := flag.String("out", ".", "Directory to write the .tf files to")
+	flag.Parse()
+
+	if *project == "" {
+		fmt.Fprintln(os.Stderr, "-project is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(context.Background(), *project, *out); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, project, out string) error {
+	config, err := mgmtConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	files, err := dvc_generate.NewGenerator(config).Generate(ctx, project)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(out, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+func mgmtConfig(ctx context.Context) (*dvc_mgmt.Configuration, error) {
+	apiURL := os.Getenv("DEVCYCLE_API_URL")
+	if apiURL == "" {
+		apiURL = "https://api.devcycle.com"
+	}
+	authURL := os.Getenv("DEVCYCLE_AUTH_URL")
+	if authURL == "" {
+		authURL = dvc_oauth.DefaultAuthURL
+	}
+
+	transport := dvc_http.NewRetryTransport(nil, dvc_http.DefaultMaxRetries, dvc_http.DefaultMaxWait)
+	config := dvc_mgmt.NewConfiguration()
+	config.BasePath = apiURL
+	config.UserAgent = "terraform-provider-devcycle"
+	config.AddDefaultHeader("dvc-referrer", "terraform")
+
+	clientId, clientSecret := os.Getenv("DEVCYCLE_CLIENT_ID"), os.Getenv("DEVCYCLE_CLIENT_SECRET")
+	switch {
+	case clientId != "" && clientSecret != "":
+		oauthTransport := *transport
+		oauthTransport.RetryPost = true
+		source := dvc_oauth.NewTokenSource(authURL, clientId, clientSecret)
+		source.HTTPClient = &http.Client{Transport: &oauthTransport}
+		if _, err := source.Token(ctx); err != nil {
+			return nil, err
+		}
+		config.HTTPClient = &http.Client{Transport: &dvc_oauth.Transport{Source: source, Base: transport}}
+	case os.Getenv("DEVCYCLE_ACCESS_TOKEN") != "":
+		config.HTTPClient = &http.Client{Transport: transport}
+		config.AddDefaultHeader("Authorization", os.Getenv("DEVCYCLE_ACCESS_TOKEN"))
+	default:
+		return nil, fmt.Errorf("set DEVCYCLE_CLIENT_ID and DEVCYCLE_CLIENT_SECRET, or DEVCYCLE_ACCESS_TOKEN")
+	}
+	return config, nil
+}
@@ -0,0 +1,245 @@
+// Package dvc_generate writes Terraform configuration for an existing DevCycle
+// project, with import blocks to bring its objects under management.
+package dvc_generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/antihax/optional"
+	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+const perPage = 100
+
+// Generator reads a project through the management API and renders it as
+// resources of this provider.
+type Generator struct {
+	Client *dvc_mgmt.DVCClient
+	// Config is the configuration Client was created with. It is used for
+	// endpoints not covered by the generated client.
+	Config *dvc_mgmt.Configuration
+}
+
+func NewGenerator(config *dvc_mgmt.Configuration) *Generator {
+	return &Generator{
+		Client: dvc_mgmt.NewAPIClient(config),
+		Config: config,
+	}
+}
+
+// audience is a project audience, as returned by the audiences endpoint.
+type audience struct {
+	Id          string      `json:"_id"`
+	Key         string      `json:"key"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Filters     interface{} `json:"filters"`
+}
+
+// filter mirrors the recursive audience filter tree of the management API.
+type filter struct {
+	Type        string        `json:"type"`
+	SubType     string        `json:"subType"`
+	Comparator  string        `json:"comparator"`
+	Values      []interface{} `json:"values"`
+	DataKey     string        `json:"dataKey"`
+	DataKeyType string        `json:"dataKeyType"`
+	Audiences   []string      `json:"_audiences"`
+	Operator    string        `json:"operator"`
+	Filters     []filter      `json:"filters"`
+}
+
+// generation holds the state of a single Generate call.
+type generation struct {
+	names   names
+	imports *hclwrite.Body
+	project dvc_mgmt.Project
+
+	// References to the generated resources, by DevCycle ID.
+	projectRef  ref
+	envRefs     map[string]ref
+	envKeys     map[string]string
+	audRefs     map[string]ref
+	featureRefs map[string]ref
+}
+
+// Generate returns the Terraform files describing the project, keyed by file
+// name: project.tf, environments.tf, audiences.tf, features.tf, targeting.tf
+// and imports.tf.
+func (g *Generator) Generate(ctx context.Context, projectKey string) (map[string][]byte, error) {
+	project, _, err := g.Client.ProjectsApi.ProjectsControllerFindOne(ctx, projectKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read project %s: %w", projectKey, err)
+	}
+	environments, err := g.environments(ctx, project.Key)
+	if err != nil {
+		return nil, err
+	}
+	audiences, err := g.audiences(ctx, project.Key)
+	if err != nil {
+		return nil, err
+	}
+	features, err := g.features(ctx, project.Key)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := g.variables(ctx, project.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	importsFile := hclwrite.NewEmptyFile()
+	gen := &generation{
+		names:   names{},
+		imports: importsFile.Body(),
+		project: project,
+		envRefs: map[string]ref{},
+		envKeys: map[string]string{},
+		audRefs: map[string]ref{},
+
+		featureRefs: map[string]ref{},
+	}
+	files := map[string]*hclwrite.File{
+		"project.tf":      gen.projectFile(),
+		"environments.tf": gen.environmentsFile(environments),
+	}
+	if files["audiences.tf"], err = gen.audiencesFile(audiences); err != nil {
+		return nil, err
+	}
+	files["features.tf"] = gen.featuresFile(features, variables)
+
+	targeting := hclwrite.NewEmptyFile()
+	for _, feature := range features {
+		configs, _, err := g.Client.FeaturesApi.FeatureConfigsControllerFindAll(ctx, feature.Key, project.Key, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read targeting of feature %s: %w", feature.Key, err)
+		}
+		if err := gen.targeting(targeting.Body(), feature, configs); err != nil {
+			return nil, err
+		}
+	}
+	files["targeting.tf"] = targeting
+	files["imports.tf"] = importsFile
+
+	ret := make(map[string][]byte, len(files))
+	for name, f := range files {
+		ret[name] = hclwrite.Format(f.Bytes())
+	}
+	return ret, nil
+}
+
+func (g *Generator) environments(ctx context.Context, project string) ([]dvc_mgmt.Environment, error) {
+	var ret []dvc_mgmt.Environment
+	for page := 1; ; page++ {
+		environments, _, err := g.Client.EnvironmentsApi.EnvironmentsControllerFindAll(ctx, project, &dvc_mgmt.EnvironmentsApiEnvironmentsControllerFindAllOpts{
+			Page:    optional.NewFloat64(float64(page)),
+			PerPage: optional.NewFloat64(perPage),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list environments: %w", err)
+		}
+		ret = append(ret, environments...)
+		if len(environments) < perPage {
+			return ret, nil
+		}
+	}
+}
+
+func (g *Generator) features(ctx context.Context, project string) ([]dvc_mgmt.Feature, error) {
+	var ret []dvc_mgmt.Feature
+	for page := 1; ; page++ {
+		features, _, err := g.Client.FeaturesApi.FeaturesControllerFindAll(ctx, project, &dvc_mgmt.FeaturesApiFeaturesControllerFindAllOpts{
+			Page:    optional.NewFloat64(float64(page)),
+			PerPage: optional.NewFloat64(perPage),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list features: %w", err)
+		}
+		ret = append(ret, features...)
+		if len(features) < perPage {
+			return ret, nil
+		}
+	}
+}
+
+func (g *Generator) variables(ctx context.Context, project string) ([]dvc_mgmt.Variable, error) {
+	var ret []dvc_mgmt.Variable
+	for page := 1; ; page++ {
+		variables, _, err := g.Client.VariablesApi.VariablesControllerFindAll(ctx, project, &dvc_mgmt.VariablesApiVariablesControllerFindAllOpts{
+			Page:    optional.NewFloat64(float64(page)),
+			PerPage: optional.NewFloat64(perPage),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to list variables: %w", err)
+		}
+		ret = append(ret, variables...)
+		if len(variables) < perPage {
+			return ret, nil
+		}
+	}
+}
+
+// audiences lists the project audiences, which the generated client does not
+// cover.
+func (g *Generator) audiences(ctx context.Context, project string) ([]audience, error) {
+	var ret []audience
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/v1/projects/%s/audiences?page=%d&perPage=%d", url.PathEscape(project), page, perPage)
+		var audiences []audience
+		if err := g.get(ctx, path, &audiences); err != nil {
+			return nil, fmt.Errorf("unable to list audiences: %w", err)
+		}
+		ret = append(ret, audiences...)
+		if len(audiences) < perPage {
+			return ret, nil
+		}
+	}
+}
+
+func (g *Generator) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(g.Config.BasePath, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	for header, value := range g.Config.DefaultHeader {
+		req.Header.Set(header, value)
+	}
+	req.Header.Set("User-Agent", g.Config.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	client := g.Config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("%s: %s", res.Status, body)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// addImport appends an import block for the resource at address.
+func (gen *generation) addImport(address ref, id string) {
+	if len(gen.imports.Blocks()) > 0 {
+		gen.imports.AppendNewline()
+	}
+	block := gen.imports.AppendNewBlock("import", nil)
+	block.Body().SetAttributeRaw("to", tokens(address))
+	block.Body().SetAttributeRaw("id", tokens(id))
+}
@@ -0,0 +1,138 @@
+package dvc_generate
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+)
+
+var testResponses = map[string]string{
+	"/v1/projects/my-project": `{"_id": "p1", "key": "my-project", "name": "My Project", "description": "Imported"}`,
+	"/v1/projects/my-project/environments": `[
+		{"_id": "e1", "_project": "p1", "key": "production", "name": "Production", "color": "#ff0000", "type": "production", "settings": {"appIconURI": ""}}
+	]`,
+	"/v1/projects/my-project/audiences": `[
+		{"_id": "a1", "key": "beta-users", "name": "Beta Users", "filters": {"operator": "and", "filters": [
+			{"type": "user", "subType": "customData", "comparator": "=", "dataKey": "beta", "dataKeyType": "Boolean", "values": [true]}
+		]}}
+	]`,
+	"/v1/projects/my-project/features": `[
+		{"_id": "f1", "_project": "p1", "key": "new-checkout", "name": "New Checkout", "type": "release", "tags": [],
+		 "variables": [
+			{"_id": "v1", "key": "checkout-limit", "name": "Checkout Limit", "type": "Number", "_feature": "f1"},
+			{"_id": "v2", "key": "checkout-config", "name": "Checkout Config", "type": "JSON", "_feature": "f1"}
+		 ],
+		 "variations": [
+			{"_id": "var-on", "key": "on", "name": "On", "variables": {"checkout-limit": 2.5, "checkout-config": {"steps": 3}}},
+			{"_id": "var-off", "key": "off", "name": "Off", "variables": {"checkout-limit": 10, "checkout-config": {}}}
+		 ]}
+	]`,
+	"/v1/projects/my-project/variables": `[
+		{"_id": "v1", "key": "checkout-limit", "type": "Number", "_feature": "f1"},
+		{"_id": "v3", "key": "orphan", "type": "String"}
+	]`,
+	"/v1/projects/my-project/features/new-checkout/configurations": `[
+		{"_feature": "f1", "_environment": "e1", "status": "active", "targets": [
+			{"name": "Beta", "audience": {"filters": {"operator": "and", "filters": [{"type": "audienceMatch", "comparator": "=", "_audiences": ["a1"]}]}},
+			 "distribution": [{"_variation": "var-on", "percentage": 1}]}
+		]}
+	]`,
+}
+
+// testGenerate generates the project served from responses, keyed by path.
+func testGenerate(t *testing.T, responses map[string]string) (map[string][]byte, error) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	config := dvc_mgmt.NewConfiguration()
+	config.BasePath = server.URL
+	return NewGenerator(config).Generate(context.Background(), "my-project")
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := testGenerate(t, testResponses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, content := range files {
+		if _, diags := hclsyntax.ParseConfig(content, name, hcl.InitialPos); diags.HasErrors() {
+			t.Fatalf("%s is not valid HCL: %s\n%s", name, diags, content)
+		}
+	}
+
+	expected := map[string][]string{
+		"project.tf":      {`resource "devcycle_project" "my-project"`, `key         = "my-project"`},
+		"environments.tf": {`resource "devcycle_environment" "production"`, `project_id  = devcycle_project.my-project.id`},
+		"audiences.tf":    {`resource "devcycle_audience" "beta-users"`, `values        = ["true"]`},
+		"features.tf": {
+			`resource "devcycle_feature" "new-checkout"`,
+			`tags        = []`,
+			`checkout-limit  = "2.5"`,
+			`checkout-config = "{\"steps\":3}"`,
+			`#   - orphan`,
+		},
+		"targeting.tf": {
+			`resource "devcycle_feature_targeting" "new-checkout_production"`,
+			`feature_id     = devcycle_feature.new-checkout.id`,
+			`audiences  = [devcycle_audience.beta-users.id]`,
+			`serve = "on"`,
+		},
+		"imports.tf": {
+			`id = "my-project/production"`,
+			`id = "p1/beta-users"`,
+			`to = devcycle_feature_targeting.new-checkout_production`,
+			`id = "p1/f1/e1"`,
+		},
+	}
+	for name, snippets := range expected {
+		for _, snippet := range snippets {
+			if !strings.Contains(string(files[name]), snippet) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, snippet, files[name])
+			}
+		}
+	}
+}
+
+func TestGenerateRejectsDeepFilters(t *testing.T) {
+	responses := make(map[string]string, len(testResponses))
+	for path, body := range testResponses {
+		responses[path] = body
+	}
+	responses["/v1/projects/my-project/audiences"] = `[
+		{"_id": "a1", "key": "beta-users", "name": "Beta Users", "filters": {"operator": "and", "filters": [
+			{"type": "op", "operator": "or", "filters": [
+				{"type": "op", "operator": "and", "filters": [
+					{"type": "user", "subType": "email", "comparator": "=", "values": ["a@example.com"]}
+				]}
+			]}
+		]}}
+	]`
+
+	_, err := testGenerate(t, responses)
+	if err == nil || !strings.Contains(err.Error(), "audience beta-users") {
+		t.Fatalf("expected an error naming the audience, got %v", err)
+	}
+}
+
+func TestGenerateRejectsInvalidFilters(t *testing.T) {
+	gen := &generation{}
+	if _, _, err := gen.filters(map[string]interface{}{"filters": "not a list"}); err == nil {
+		t.Fatal("expected filters that cannot be parsed to be rejected")
+	}
+}
@@ -0,0 +1,138 @@
+package dvc_generate
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// object is an HCL object whose attributes are written in order.
+type object []field
+
+type field struct {
+	name  string
+	value interface{}
+}
+
+// ref is a reference to another resource's attribute, such as
+// devcycle_project.main.id.
+type ref string
+
+// tokens converts strings, booleans, numbers, refs, objects and lists of them to
+// HCL expression tokens.
+func tokens(value interface{}) hclwrite.Tokens {
+	switch v := value.(type) {
+	case ref:
+		var traversal hcl.Traversal
+		for i, name := range strings.Split(string(v), ".") {
+			if i == 0 {
+				traversal = append(traversal, hcl.TraverseRoot{Name: name})
+			} else {
+				traversal = append(traversal, hcl.TraverseAttr{Name: name})
+			}
+		}
+		return hclwrite.TokensForTraversal(traversal)
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case []string:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, e := range v {
+			elems = append(elems, tokens(e))
+		}
+		return hclwrite.TokensForTuple(elems)
+	case []interface{}:
+		elems := make([]hclwrite.Tokens, 0, len(v))
+		for _, e := range v {
+			elems = append(elems, tokens(e))
+		}
+		return hclwrite.TokensForTuple(elems)
+	case []object:
+		// Unlike hclwrite.TokensForTuple, put each object on its own lines.
+		toks := hclwrite.Tokens{{Type: hclsyntax.TokenOBrack, Bytes: []byte("[")}}
+		if len(v) > 0 {
+			toks = append(toks, &hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")})
+		}
+		for _, e := range v {
+			toks = append(toks, tokens(e)...)
+			toks = append(toks,
+				&hclwrite.Token{Type: hclsyntax.TokenComma, Bytes: []byte(",")},
+				&hclwrite.Token{Type: hclsyntax.TokenNewline, Bytes: []byte("\n")},
+			)
+		}
+		return append(toks, &hclwrite.Token{Type: hclsyntax.TokenCBrack, Bytes: []byte("]")})
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		obj := make(object, 0, len(keys))
+		for _, k := range keys {
+			obj = append(obj, field{k, v[k]})
+		}
+		return tokens(obj)
+	case object:
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(v))
+		for _, f := range v {
+			name := hclwrite.TokensForIdentifier(f.name)
+			if !validIdentifier(f.name) {
+				name = tokens(f.name)
+			}
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: name, Value: tokens(f.value)})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	panic("dvc_generate: unsupported value type")
+}
+
+// setAttributes writes the fields of obj as attributes of body, skipping nil
+// values.
+func setAttributes(body *hclwrite.Body, obj object) {
+	for _, f := range obj {
+		if f.value == nil {
+			continue
+		}
+		body.SetAttributeRaw(f.name, tokens(f.value))
+	}
+}
+
+var identifierPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+func validIdentifier(s string) bool {
+	return identifierPattern.MatchString(s)
+}
+
+// names hands out unique resource names per resource type.
+type names map[string]map[string]bool
+
+// name returns a resource name for resourceType derived from parts, such as
+// feature and environment keys, adding a numeric suffix on collisions.
+func (n names) name(resourceType string, parts ...string) string {
+	base := invalidNameChars.ReplaceAllString(strings.Join(parts, "_"), "_")
+	if base == "" || !validIdentifier(base) {
+		base = "_" + base
+	}
+
+	used := n[resourceType]
+	if used == nil {
+		used = make(map[string]bool)
+		n[resourceType] = used
+	}
+	name := base
+	for i := 2; used[name]; i++ {
+		name = base + "_" + strconv.Itoa(i)
+	}
+	used[name] = true
+	return name
+}
@@ -0,0 +1,356 @@
+package dvc_generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// newResource appends a resource block to body, adding its import block.
+func (gen *generation) newResource(body *hclwrite.Body, resourceType, name, importId string, attributes object) ref {
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	block := body.AppendNewBlock("resource", []string{resourceType, name})
+	setAttributes(block.Body(), attributes)
+
+	address := ref(resourceType + "." + name)
+	gen.addImport(address, importId)
+	return address
+}
+
+func (gen *generation) projectFile() *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	project := gen.project
+	gen.projectRef = gen.newResource(f.Body(), "devcycle_project", gen.names.name("devcycle_project", project.Key), project.Key, object{
+		{"name", project.Name},
+		{"key", project.Key},
+		{"description", project.Description},
+	})
+	return f
+}
+
+func (gen *generation) environmentsFile(environments []dvc_mgmt.Environment) *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+	sort.Slice(environments, func(i, j int) bool { return environments[i].Key < environments[j].Key })
+	for _, environment := range environments {
+		// Settings are optional, and only written when there is one to keep.
+		var settings interface{}
+		if environment.Settings != nil && environment.Settings.AppIconURI != "" {
+			settings = object{{"app_icon_uri", environment.Settings.AppIconURI}}
+		}
+		address := gen.newResource(f.Body(), "devcycle_environment", gen.names.name("devcycle_environment", environment.Key), gen.project.Key+"/"+environment.Key, object{
+			{"project_id", gen.projectRef + ".id"},
+			{"name", environment.Name},
+			{"key", environment.Key},
+			{"description", environment.Description},
+			{"color", environment.Color},
+			{"type", environment.Type_},
+			{"settings", settings},
+		})
+		gen.envRefs[environment.Id] = address
+		gen.envKeys[environment.Id] = environment.Key
+	}
+	return f
+}
+
+func (gen *generation) audiencesFile(audiences []audience) (*hclwrite.File, error) {
+	f := hclwrite.NewEmptyFile()
+	sort.Slice(audiences, func(i, j int) bool { return audiences[i].Key < audiences[j].Key })
+
+	// Name every audience first, so that audiences matching other audiences
+	// can reference them regardless of order.
+	names := make([]string, len(audiences))
+	for i, aud := range audiences {
+		names[i] = gen.names.name("devcycle_audience", aud.Key)
+		gen.audRefs[aud.Id] = ref("devcycle_audience." + names[i])
+	}
+
+	for i, aud := range audiences {
+		operator, filters, err := gen.filters(aud.Filters)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert the filters of audience %s: %w", aud.Key, err)
+		}
+		var description interface{}
+		if aud.Description != "" {
+			description = aud.Description
+		}
+		// The audience resource keeps the project of its import identifier, so
+		// it is imported by project ID to match the project_id reference.
+		gen.newResource(f.Body(), "devcycle_audience", names[i], gen.project.Id+"/"+aud.Key, object{
+			{"project_id", gen.projectRef + ".id"},
+			{"key", aud.Key},
+			{"name", aud.Name},
+			{"description", description},
+			{"operator", operator},
+			{"filters", filters},
+		})
+	}
+	return f, nil
+}
+
+func (gen *generation) featuresFile(features []dvc_mgmt.Feature, variables []dvc_mgmt.Variable) *hclwrite.File {
+	f := hclwrite.NewEmptyFile()
+
+	var unattached []string
+	for _, variable := range variables {
+		if variable.Feature == "" {
+			unattached = append(unattached, variable.Key)
+		}
+	}
+	if len(unattached) > 0 {
+		sort.Strings(unattached)
+		f.Body().AppendUnstructuredTokens(comment("Variables not attached to a feature cannot be managed by this provider and were skipped:"))
+		for _, key := range unattached {
+			f.Body().AppendUnstructuredTokens(comment("  - " + key))
+		}
+		f.Body().AppendNewline()
+	}
+
+	sort.Slice(features, func(i, j int) bool { return features[i].Key < features[j].Key })
+	for _, feature := range features {
+		variableTypes := make(map[string]string)
+		sort.Slice(feature.Variables, func(i, j int) bool { return feature.Variables[i].Key < feature.Variables[j].Key })
+		var vars []object
+		for _, variable := range feature.Variables {
+			variableTypes[variable.Key] = variable.Type_
+			vars = append(vars, object{
+				{"key", variable.Key},
+				{"type", variable.Type_},
+				{"name", variable.Name},
+				{"description", variable.Description},
+			})
+		}
+
+		sort.Slice(feature.Variations, func(i, j int) bool { return feature.Variations[i].Key < feature.Variations[j].Key })
+		var variations []object
+		for _, variation := range feature.Variations {
+			values := make(map[string]string, len(variation.Variables))
+			for key, value := range variation.Variables {
+				values[key] = variationValue(variableTypes[key], value)
+			}
+			variations = append(variations, object{
+				{"key", variation.Key},
+				{"name", variation.Name},
+				{"variables", values},
+			})
+		}
+
+		attributes := object{
+			{"project_id", gen.projectRef + ".id"},
+			{"name", feature.Name},
+			{"key", feature.Key},
+			{"description", feature.Description},
+			{"type", feature.Type_},
+		}
+		if feature.Tags != nil {
+			attributes = append(attributes, field{"tags", feature.Tags})
+		}
+		if vars != nil {
+			attributes = append(attributes, field{"variables", vars})
+		}
+		if variations != nil {
+			attributes = append(attributes, field{"variations", variations})
+		}
+		gen.featureRefs[feature.Id] = gen.newResource(f.Body(), "devcycle_feature", gen.names.name("devcycle_feature", feature.Key), gen.project.Key+"/"+feature.Key, attributes)
+	}
+	return f
+}
+
+// targeting appends the targeting of a feature in every environment where it
+// is enabled or has targets.
+func (gen *generation) targeting(body *hclwrite.Body, feature dvc_mgmt.Feature, configs []dvc_mgmt.FeatureConfig) error {
+	variationKeys := make(map[string]string)
+	for _, variation := range feature.Variations {
+		variationKeys[variation.Id] = variation.Key
+	}
+	variationKey := func(v string) string {
+		if key, ok := variationKeys[v]; ok {
+			return key
+		}
+		return v
+	}
+
+	sort.Slice(configs, func(i, j int) bool {
+		return gen.envKeys[configs[i].Environment] < gen.envKeys[configs[j].Environment]
+	})
+	for _, config := range configs {
+		envRef, ok := gen.envRefs[config.Environment]
+		if !ok || (config.Status != "active" && len(config.Targets) == 0) {
+			continue
+		}
+
+		var targets []object
+		for _, target := range config.Targets {
+			t := object{}
+			if target.Name != "" {
+				t = append(t, field{"name", target.Name})
+			}
+			if target.Audience != nil {
+				operator, filters, err := gen.filters(target.Audience.Filters)
+				if err != nil {
+					return fmt.Errorf("unable to convert the targeting filters of feature %s in environment %s: %w", feature.Key, gen.envKeys[config.Environment], err)
+				}
+				audience := object{}
+				if target.Audience.Name != "" {
+					audience = append(audience, field{"name", target.Audience.Name})
+				}
+				audience = append(audience, field{"operator", operator}, field{"filters", filters})
+				t = append(t, field{"audience", audience})
+			}
+
+			if len(target.Distribution) == 1 && target.Distribution[0].Percentage == 1 {
+				t = append(t, field{"serve", variationKey(target.Distribution[0].Variation)})
+			} else {
+				var distribution []object
+				for _, d := range target.Distribution {
+					distribution = append(distribution, object{
+						{"variation", variationKey(d.Variation)},
+						{"percentage", d.Percentage},
+					})
+				}
+				t = append(t, field{"distribution", distribution})
+			}
+
+			if target.Rollout != nil {
+				rolloutType, _ := target.Rollout.Type_.(string)
+				rollout := object{{"type", rolloutType}}
+				if target.Rollout.StartPercentage != 0 {
+					rollout = append(rollout, field{"start_percentage", target.Rollout.StartPercentage})
+				}
+				rollout = append(rollout, field{"start_date", target.Rollout.StartDate.UTC().Format(time.RFC3339)})
+				var stages []object
+				for _, stage := range target.Rollout.Stages {
+					stageType, _ := stage.Type_.(string)
+					stages = append(stages, object{
+						{"type", stageType},
+						{"percentage", stage.Percentage},
+						{"date", stage.Date.UTC().Format(time.RFC3339)},
+					})
+				}
+				if stages != nil {
+					rollout = append(rollout, field{"stages", stages})
+				}
+				t = append(t, field{"rollout", rollout})
+			}
+			targets = append(targets, t)
+		}
+
+		attributes := object{
+			{"project_id", gen.projectRef + ".id"},
+			{"feature_id", gen.featureRefs[feature.Id] + ".id"},
+			{"environment_id", envRef + ".id"},
+			{"enabled", config.Status == "active"},
+		}
+		if targets != nil {
+			attributes = append(attributes, field{"targets", targets})
+		}
+		name := gen.names.name("devcycle_feature_targeting", feature.Key, gen.envKeys[config.Environment])
+		gen.newResource(body, "devcycle_feature_targeting", name, gen.project.Id+"/"+feature.Id+"/"+config.Environment, attributes)
+	}
+	return nil
+}
+
+// filters converts an audience filter tree to the operator and filters
+// attributes of the audience and feature targeting resources. The resources
+// hold two levels of filters, so deeper trees are rejected rather than
+// generating configuration that would drop their conditions.
+func (gen *generation) filters(raw interface{}) (string, []object, error) {
+	var root filter
+	marshalled, err := json.Marshal(raw)
+	if err == nil {
+		err = json.Unmarshal(marshalled, &root)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	var groups []object
+	for i, f := range root.Filters {
+		group := gen.filter(f)
+		if f.Operator != "" {
+			group = append(group, field{"operator", f.Operator})
+		}
+		if f.Filters != nil {
+			var nested []object
+			for j, n := range f.Filters {
+				if len(n.Filters) > 0 {
+					return "", nil, fmt.Errorf("filter %d has a nested filter %d grouping filters of its own, which is more than the two levels of filters the provider supports", i, j)
+				}
+				nested = append(nested, gen.filter(n))
+			}
+			group = append(group, field{"filters", nested})
+		}
+		groups = append(groups, group)
+	}
+	return root.Operator, groups, nil
+}
+
+func (gen *generation) filter(f filter) object {
+	ret := object{{"type", f.Type}}
+	optionalField := func(name, value string) {
+		if value != "" {
+			ret = append(ret, field{name, value})
+		}
+	}
+	optionalField("sub_type", f.SubType)
+	optionalField("comparator", f.Comparator)
+	optionalField("data_key", f.DataKey)
+	optionalField("data_key_type", f.DataKeyType)
+	if len(f.Values) > 0 {
+		values := make([]string, 0, len(f.Values))
+		for _, v := range f.Values {
+			values = append(values, scalarString(v))
+		}
+		ret = append(ret, field{"values", values})
+	}
+	if len(f.Audiences) > 0 {
+		audiences := make([]interface{}, 0, len(f.Audiences))
+		for _, id := range f.Audiences {
+			if aud, ok := gen.audRefs[id]; ok {
+				audiences = append(audiences, aud+".id")
+			} else {
+				audiences = append(audiences, id)
+			}
+		}
+		ret = append(ret, field{"audiences", audiences})
+	}
+	return ret
+}
+
+// variationValue formats a variation value the way the feature resource
+// stores it for the variable's type.
+func variationValue(variableType string, value interface{}) string {
+	if variableType == "JSON" {
+		if marshalled, err := json.Marshal(value); err == nil {
+			return string(marshalled)
+		}
+	}
+	return scalarString(value)
+}
+
+func scalarString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(marshalled)
+}
+
+func comment(text string) hclwrite.Tokens {
+	return hclwrite.Tokens{{Type: hclsyntax.TokenComment, Bytes: []byte("# " + text + "\n")}}
+}
@@ -0,0 +1,174 @@
+package dvc_http
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries = 3
+	DefaultMaxWait    = 30 * time.Second
+	defaultMinWait    = 500 * time.Millisecond
+)
+
+// RetryTransport is an http.RoundTripper shared by the management API and
+// OAuth clients. It retries requests that were rate limited or failed with a
+// transient error, waiting with jittered exponential backoff or for as long as
+// the server asks with Retry-After.
+//
+// Safe and idempotent requests are retried on rate limits, transient 5xx
+// responses and network errors. Other requests are only retried when rate
+// limited, as the server has not processed them.
+type RetryTransport struct {
+	// Base is the RoundTripper used to send requests. http.DefaultTransport
+	// is used when nil.
+	Base http.RoundTripper
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+	// MaxWait caps the wait before a single retry. DefaultMaxWait is used
+	// when it is not greater than zero.
+	MaxWait time.Duration
+	// RetryPost also retries POST requests like idempotent ones. It is meant
+	// for endpoints such as token requests where repeating a POST is harmless.
+	RetryPost bool
+
+	minWait time.Duration
+	sleep   func(context.Context, time.Duration) error
+}
+
+func NewRetryTransport(base http.RoundTripper, maxRetries int, maxWait time.Duration) *RetryTransport {
+	return &RetryTransport{
+		Base:       base,
+		MaxRetries: maxRetries,
+		MaxWait:    maxWait,
+	}
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *RetryTransport) idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return t.RetryPost
+	}
+	return false
+}
+
+func (t *RetryTransport) shouldRetry(req *http.Request, res *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return t.idempotent(req.Method)
+	}
+	switch res.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return t.idempotent(req.Method)
+	}
+	return false
+}
+
+// backoff returns the wait before retry number attempt, starting at 0.
+func (t *RetryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	maxWait := t.MaxWait
+	if maxWait <= 0 {
+		maxWait = DefaultMaxWait
+	}
+
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if wait > maxWait {
+				return maxWait
+			}
+			return wait
+		}
+	}
+
+	wait := t.minWait
+	if wait <= 0 {
+		wait = defaultMinWait
+	}
+	for i := 0; i < attempt && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		wait = maxWait
+	}
+	// Jitter between half and the full wait spreads out concurrent retries.
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
+
+// retryAfter parses a Retry-After header, given either in seconds or as an
+// HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sleep := t.sleep
+	if sleep == nil {
+		sleep = sleepContext
+	}
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(req.Context())
+			attemptReq.Body = body
+		}
+
+		res, err := t.base().RoundTrip(attemptReq)
+		if attempt >= t.MaxRetries || !replayable || !t.shouldRetry(req, res, err) {
+			return res, err
+		}
+
+		wait := t.backoff(attempt, res)
+		if res != nil {
+			res.Body.Close()
+		}
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
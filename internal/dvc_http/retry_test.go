@@ -0,0 +1,95 @@
+package dvc_http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testTransport(maxRetries int, waits *[]time.Duration) *RetryTransport {
+	t := NewRetryTransport(nil, maxRetries, 10*time.Second)
+	t.sleep = func(ctx context.Context, d time.Duration) error {
+		*waits = append(*waits, d)
+		return nil
+	}
+	return t
+}
+
+func TestRetryTransportRetriesRateLimits(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("expected the body to be replayed, got %q", body)
+		}
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: testTransport(3, &waits)}
+	res, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK || calls != 3 {
+		t.Fatalf("expected success after 3 calls, got status %d after %d calls", res.StatusCode, calls)
+	}
+	for _, wait := range waits {
+		if wait != 2*time.Second {
+			t.Fatalf("expected Retry-After to be honored, waited %s", wait)
+		}
+	}
+}
+
+func TestRetryTransportDoesNotRetryUnsafeServerErrors(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	client := &http.Client{Transport: testTransport(3, &waits)}
+
+	req, _ := http.NewRequest(http.MethodPatch, server.URL, strings.NewReader("{}"))
+	res, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if calls != 1 {
+		t.Fatalf("expected PATCH not to be retried on 503, got %d calls", calls)
+	}
+
+	res, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if calls != 5 {
+		t.Fatalf("expected GET to be retried 3 times, got %d calls in total", calls)
+	}
+}
+
+func TestRetryTransportBackoff(t *testing.T) {
+	transport := NewRetryTransport(nil, 5, 4*time.Second)
+	for attempt := 0; attempt < 6; attempt++ {
+		wait := transport.backoff(attempt, nil)
+		if wait > 4*time.Second || wait < defaultMinWait/2 {
+			t.Fatalf("backoff for attempt %d out of range: %s", attempt, wait)
+		}
+	}
+}
@@ -1,36 +1,159 @@
 package dvc_oauth
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 )
 
+// DefaultAuthURL is the DevCycle OAuth token endpoint.
+const DefaultAuthURL = "https://auth.devcycle.com/oauth/token"
+
+// Audience is the API the requested access tokens are issued for.
+const Audience = "https://api.devcycle.com/"
+
+var (
+	// ErrInvalidClient is returned when the client credentials are rejected.
+	ErrInvalidClient = errors.New("invalid client credentials")
+	// ErrUnauthorizedAudience is returned when the client is valid but not
+	// allowed to request tokens for the DevCycle API.
+	ErrUnauthorizedAudience = errors.New("client is not authorized for the DevCycle API audience")
+	// ErrMalformedResponse is returned when the token endpoint response cannot
+	// be understood.
+	ErrMalformedResponse = errors.New("malformed token response")
+	// ErrTokenRequest is returned for any other rejected token request.
+	ErrTokenRequest = errors.New("token request failed")
+)
+
+// Error describes a token request rejected by the token endpoint. Use
+// errors.Is with the Err* values to check its kind.
+type Error struct {
+	Kind        error
+	StatusCode  int
+	Code        string
+	Description string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("%s (HTTP %d)", e.Kind, e.StatusCode)
+	if e.Code != "" {
+		msg += ": " + e.Code
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Kind
+}
+
+// NetworkError is returned when the token endpoint cannot be reached.
+type NetworkError struct {
+	URL string
+	Err error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("unable to reach %s: %s", e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
 type Auth0 struct {
 	AccessToken string `json:"access_token"`
 	ExpiresIn   int    `json:"expires_in"`
 	TokenType   string `json:"token_type"`
 }
 
+type errorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
 func GetAuthToken(clientId, clientSecret string) (Auth0, error) {
-	url := "https://auth.devcycle.com/oauth/token"
+	return GetAuthTokenFromURL(DefaultAuthURL, clientId, clientSecret)
+}
 
-	payload := strings.NewReader("grant_type=client_credentials&client_id=" + clientId + "&client_secret=" + clientSecret + "&audience=https://api.devcycle.com/")
+// GetAuthTokenFromURL exchanges client credentials for an access token at the
+// given token endpoint.
+func GetAuthTokenFromURL(url, clientId, clientSecret string) (Auth0, error) {
+	return GetAuthTokenWithContext(context.Background(), url, clientId, clientSecret)
+}
 
-	req, _ := http.NewRequest("POST", url, payload)
+// GetAuthTokenWithContext exchanges client credentials for an access token at
+// the given token endpoint, giving up when ctx is done.
+func GetAuthTokenWithContext(ctx context.Context, authURL, clientId, clientSecret string) (Auth0, error) {
+	return requestToken(ctx, http.DefaultClient, authURL, clientId, clientSecret)
+}
 
-	req.Header.Add("content-type", "application/x-www-form-urlencoded")
+func requestToken(ctx context.Context, client *http.Client, authURL, clientId, clientSecret string) (Auth0, error) {
+	var auth Auth0
 
-	res, _ := http.DefaultClient.Do(req)
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {clientId},
+		"client_secret": {clientSecret},
+		"audience":      {Audience},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, authURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return auth, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
 
+	res, err := client.Do(req)
+	if err != nil {
+		return auth, &NetworkError{URL: authURL, Err: err}
+	}
 	defer res.Body.Close()
-	body, _ := io.ReadAll(res.Body)
-	var auth Auth0
-	err := json.Unmarshal(body, &auth)
+
+	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return auth, err
+		return auth, &NetworkError{URL: authURL, Err: err}
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return auth, tokenError(res.StatusCode, body)
 	}
 
+	if err := json.Unmarshal(body, &auth); err != nil {
+		return auth, &Error{Kind: ErrMalformedResponse, StatusCode: res.StatusCode, Description: err.Error()}
+	}
+	if auth.AccessToken == "" {
+		return auth, &Error{Kind: ErrMalformedResponse, StatusCode: res.StatusCode, Description: "no access_token in response"}
+	}
 	return auth, nil
 }
+
+func tokenError(statusCode int, body []byte) error {
+	var errResp errorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error == "" {
+		kind := ErrTokenRequest
+		if statusCode == http.StatusUnauthorized {
+			kind = ErrInvalidClient
+		}
+		return &Error{Kind: kind, StatusCode: statusCode, Description: strings.TrimSpace(string(body))}
+	}
+
+	kind := ErrTokenRequest
+	description := strings.ToLower(errResp.ErrorDescription)
+	switch {
+	case errResp.Error == "invalid_client":
+		kind = ErrInvalidClient
+	case errResp.Error == "unauthorized_client", strings.Contains(description, "not authorized to access"):
+		kind = ErrUnauthorizedAudience
+	case errResp.Error == "access_denied" && strings.Contains(description, "unauthorized"):
+		kind = ErrInvalidClient
+	}
+	return &Error{Kind: kind, StatusCode: statusCode, Code: errResp.Error, Description: errResp.ErrorDescription}
+}
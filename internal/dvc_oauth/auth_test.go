@@ -0,0 +1,66 @@
+package dvc_oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAuthTokenErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"invalid client", http.StatusUnauthorized, `{"error": "access_denied", "error_description": "Unauthorized"}`, ErrInvalidClient},
+		{"invalid client code", http.StatusUnauthorized, `{"error": "invalid_client"}`, ErrInvalidClient},
+		{"unauthorized audience", http.StatusForbidden, `{"error": "access_denied", "error_description": "Client is not authorized to access \"https://api.devcycle.com/\"."}`, ErrUnauthorizedAudience},
+		{"malformed", http.StatusOK, `<html></html>`, ErrMalformedResponse},
+		{"missing token", http.StatusOK, `{}`, ErrMalformedResponse},
+		{"server error", http.StatusBadGateway, `bad gateway`, ErrTokenRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := GetAuthTokenFromURL(server.URL, "id", "secret")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGetAuthTokenEncodesForm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("client_secret") != "a&b=c" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token": "token", "expires_in": 60}`))
+	}))
+	defer server.Close()
+
+	auth, err := GetAuthTokenFromURL(server.URL, "id", "a&b=c")
+	if err != nil || auth.AccessToken != "token" {
+		t.Fatalf("expected token, got %q, %v", auth.AccessToken, err)
+	}
+}
+
+func TestGetAuthTokenNetworkError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GetAuthTokenWithContext(ctx, "http://127.0.0.1:1", "id", "secret")
+	var networkErr *NetworkError
+	if !errors.As(err, &networkErr) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancelled network error, got %v", err)
+	}
+}
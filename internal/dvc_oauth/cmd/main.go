@@ -7,9 +7,14 @@ import (
 )
 
 func main() {
-	token, err := dvc_oauth.GetAuthToken(os.Getenv("DEVCYCLE_CLIENT_ID"), os.Getenv("DEVCYCLE_CLIENT_SECRET"))
+	authURL := os.Getenv("DEVCYCLE_AUTH_URL")
+	if authURL == "" {
+		authURL = dvc_oauth.DefaultAuthURL
+	}
+	token, err := dvc_oauth.GetAuthTokenFromURL(authURL, os.Getenv("DEVCYCLE_CLIENT_ID"), os.Getenv("DEVCYCLE_CLIENT_SECRET"))
 	if err != nil {
-		return
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 	fmt.Println(token.AccessToken)
 }
@@ -0,0 +1,132 @@
+package dvc_oauth
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// expiryDelta is how long before its expiry a cached token is refreshed, so
+// that requests in flight never carry an expired token.
+const expiryDelta = time.Minute
+
+// TokenSource caches an access token obtained with client credentials and
+// refreshes it before it expires. It is safe for concurrent use.
+type TokenSource struct {
+	AuthURL      string
+	ClientId     string
+	ClientSecret string
+	// HTTPClient is used to request tokens. http.DefaultClient is used when nil.
+	HTTPClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+	now    func() time.Time
+}
+
+func NewTokenSource(authURL, clientId, clientSecret string) *TokenSource {
+	return &TokenSource{
+		AuthURL:      authURL,
+		ClientId:     clientId,
+		ClientSecret: clientSecret,
+		now:          time.Now,
+	}
+}
+
+// Token returns the cached access token, fetching a new one when there is no
+// token yet or the cached one is about to expire.
+func (s *TokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || s.now().Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	client := s.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	auth, err := requestToken(ctx, client, s.AuthURL, s.ClientId, s.ClientSecret)
+	if err != nil {
+		return "", err
+	}
+	s.token = auth.AccessToken
+	s.expiry = time.Time{}
+	if auth.ExpiresIn > 0 {
+		lifetime := time.Duration(auth.ExpiresIn) * time.Second
+		delta := expiryDelta
+		if lifetime <= 2*delta {
+			delta = lifetime / 2
+		}
+		s.expiry = s.now().Add(lifetime - delta)
+	}
+	return s.token, nil
+}
+
+// Invalidate discards token if it is still the cached token, so that the next
+// call to Token fetches a new one. Tokens already replaced are ignored, which
+// avoids refreshing once per request when many requests fail together.
+func (s *TokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// Transport is an http.RoundTripper that authorizes requests with tokens from
+// Source. A request rejected with 401 is retried once with a fresh token when
+// its body can be replayed.
+type Transport struct {
+	Source *TokenSource
+	// Base is the RoundTripper used to send requests. http.DefaultTransport
+	// is used when nil.
+	Base http.RoundTripper
+}
+
+func (t *Transport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", token)
+	res, err := t.base().RoundTrip(authorized)
+	if err != nil || res.StatusCode != http.StatusUnauthorized {
+		return res, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return res, nil
+	}
+
+	t.Source.Invalidate(token)
+	token, err = t.Source.Token(req.Context())
+	if err != nil {
+		// Surface the original 401 rather than the refresh failure.
+		return res, nil
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return res, nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", token)
+	res.Body.Close()
+	return t.base().RoundTrip(retry)
+}
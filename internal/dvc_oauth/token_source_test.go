@@ -0,0 +1,69 @@
+package dvc_oauth
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenSourceRefresh(t *testing.T) {
+	var issued int32
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&issued, 1)
+		fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": 3600, "token_type": "Bearer"}`, n)
+	}))
+	defer auth.Close()
+
+	now := time.Now()
+	source := NewTokenSource(auth.URL, "id", "secret")
+	source.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil || token != "token-1" {
+			t.Fatalf("expected cached token-1, got %q, %v", token, err)
+		}
+	}
+
+	now = now.Add(time.Hour - expiryDelta)
+	if token, _ := source.Token(context.Background()); token != "token-2" {
+		t.Fatalf("expected token-2 after expiry, got %q", token)
+	}
+
+	source.Invalidate("token-1")
+	if token, _ := source.Token(context.Background()); token != "token-2" {
+		t.Fatalf("invalidating a replaced token should keep token-2, got %q", token)
+	}
+}
+
+func TestTransportRetriesUnauthorized(t *testing.T) {
+	var issued int32
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&issued, 1)
+		fmt.Fprintf(w, `{"access_token": "token-%d", "expires_in": 3600, "token_type": "Bearer"}`, n)
+	}))
+	defer auth.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer api.Close()
+
+	client := &http.Client{Transport: &Transport{Source: NewTokenSource(auth.URL, "id", "secret")}}
+	res, err := client.Get(api.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected the request to be retried with a fresh token, got status %d", res.StatusCode)
+	}
+}
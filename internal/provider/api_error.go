@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// apiErrorBody is the error body returned by the DevCycle management API.
+// message is either a single string or, for validation errors, a list with one
+// entry per invalid field.
+type apiErrorBody struct {
+	StatusCode int             `json:"statusCode"`
+	Message    json.RawMessage `json:"message"`
+	Error      string          `json:"error"`
+}
+
+func (b apiErrorBody) messages() []string {
+	if len(b.Message) == 0 {
+		return nil
+	}
+	var message string
+	if err := json.Unmarshal(b.Message, &message); err == nil {
+		if message == "" {
+			return nil
+		}
+		return []string{message}
+	}
+	var messages []string
+	if err := json.Unmarshal(b.Message, &messages); err == nil {
+		return messages
+	}
+	return []string{string(b.Message)}
+}
+
+// apiErrorSummary returns the diagnostic summary for a failed request, by class
+// of failure.
+func apiErrorSummary(statusCode int) string {
+	switch {
+	case statusCode == http.StatusBadRequest, statusCode == http.StatusUnprocessableEntity:
+		return "Invalid DevCycle Request"
+	case statusCode == http.StatusUnauthorized:
+		return "DevCycle Authentication Failed"
+	case statusCode == http.StatusForbidden:
+		return "DevCycle Permission Denied"
+	case statusCode == http.StatusNotFound:
+		return "DevCycle Resource Not Found"
+	case statusCode == http.StatusConflict:
+		return "DevCycle Resource Conflict"
+	case statusCode == http.StatusTooManyRequests:
+		return "DevCycle Rate Limit Exceeded"
+	case statusCode >= 500:
+		return "DevCycle Server Error"
+	}
+	return "Client Error"
+}
+
+// apiErrorHint returns guidance appended to the diagnostic detail, if any.
+// Authentication failures name authSource, the origin of the credentials, when
+// it is known.
+func apiErrorHint(statusCode int, authSource string) string {
+	credentials := "the provider credentials"
+	if authSource != "" {
+		credentials = fmt.Sprintf("the credentials from the %s", authSource)
+	}
+	switch statusCode {
+	case http.StatusUnauthorized:
+		return fmt.Sprintf("Check %s. Access tokens expire, so prefer client_id and client_secret, which are refreshed automatically.", credentials)
+	case http.StatusForbidden:
+		return fmt.Sprintf("Authentication with %s succeeded, but they are not allowed to perform this request.", credentials)
+	case http.StatusConflict:
+		return "A resource with the same key may already exist. Import it instead of creating it."
+	case http.StatusTooManyRequests:
+		return "Retries were exhausted. Increase max_retries or retry_max_wait, or reduce Terraform's parallelism."
+	}
+	return ""
+}
+
+// apiErrorAttributePath maps a validation message such as
+// "variations.0.key must be a string" to the attribute it refers to, returning
+// nil when the leading field is not an attribute of schema.
+func apiErrorAttributePath(schema tfsdk.Schema, message string) *tftypes.AttributePath {
+	field := strings.SplitN(message, " ", 2)[0]
+	if field == "" || len(schema.Attributes) == 0 {
+		return nil
+	}
+
+	var steps []func(*tftypes.AttributePath) *tftypes.AttributePath
+	for _, segment := range strings.Split(field, ".") {
+		segment := segment
+		if index, err := strconv.Atoi(segment); err == nil {
+			steps = append(steps, func(p *tftypes.AttributePath) *tftypes.AttributePath {
+				return p.WithElementKeyInt(index)
+			})
+			continue
+		}
+		name := snakeCase(strings.TrimPrefix(segment, "_"))
+		steps = append(steps, func(p *tftypes.AttributePath) *tftypes.AttributePath {
+			return p.WithAttributeName(name)
+		})
+	}
+
+	// Fall back to the closest enclosing attribute of the schema.
+	for n := len(steps); n > 0; n-- {
+		path := tftypes.NewAttributePath()
+		for _, step := range steps[:n] {
+			path = step(path)
+		}
+		if _, err := schema.AttributeAtPath(path); err == nil {
+			return path
+		}
+	}
+	return nil
+}
+
+func snakeCase(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isNotFound reports whether a management API request failed because the
+// object it refers to does not exist.
+func isNotFound(httpResponse *http.Response) bool {
+	return httpResponse != nil && httpResponse.StatusCode == http.StatusNotFound
+}
+
+// handleDevCycleHTTPForSchema is handleDevCycleHTTP for requests sending the
+// attributes of schema, attaching validation errors to the attributes they
+// refer to.
+func handleDevCycleHTTPForSchema(err error, httpResponse *http.Response, authSource string, schema tfsdk.Schema, resp *diag.Diagnostics) bool {
+	if err == nil && httpResponse != nil && httpResponse.StatusCode >= 200 && httpResponse.StatusCode <= 299 {
+		return false
+	}
+
+	request := "DevCycle API request"
+	if httpResponse != nil && httpResponse.Request != nil {
+		request = fmt.Sprintf("%s %s", httpResponse.Request.Method, httpResponse.Request.URL.Path)
+	} else {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			request = fmt.Sprintf("%s %s", urlErr.Op, urlErr.URL)
+		}
+	}
+
+	if httpResponse == nil {
+		resp.AddError("Unable to reach DevCycle", fmt.Sprintf("%s failed: %s", request, err))
+		return true
+	}
+
+	body := apiErrorBody{StatusCode: httpResponse.StatusCode}
+	var bodyErr interface{ Body() []byte }
+	if errors.As(err, &bodyErr) {
+		_ = json.Unmarshal(bodyErr.Body(), &body)
+	}
+
+	summary := apiErrorSummary(httpResponse.StatusCode)
+	status := httpResponse.Status
+	if body.Error != "" {
+		status = fmt.Sprintf("%d %s", httpResponse.StatusCode, body.Error)
+	}
+	detail := func(messages []string) string {
+		detail := fmt.Sprintf("%s returned %s", request, status)
+		if len(messages) > 0 {
+			detail += ":\n\n" + strings.Join(messages, "\n")
+		} else if err != nil {
+			detail += fmt.Sprintf(": %s", err)
+		}
+		if hint := apiErrorHint(httpResponse.StatusCode, authSource); hint != "" {
+			detail += "\n\n" + hint
+		}
+		return detail
+	}
+
+	messages := body.messages()
+	validation := httpResponse.StatusCode == http.StatusBadRequest || httpResponse.StatusCode == http.StatusUnprocessableEntity
+	var unmatched []string
+	for _, message := range messages {
+		if path := apiErrorAttributePath(schema, message); validation && path != nil {
+			resp.AddAttributeError(path, summary, detail([]string{message}))
+			continue
+		}
+		unmatched = append(unmatched, message)
+	}
+	if len(unmatched) > 0 || len(messages) == 0 {
+		resp.AddError(summary, detail(unmatched))
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHandleDevCycleHTTPValidationErrors(t *testing.T) {
+	schema := tfsdk.Schema{
+		Attributes: map[string]tfsdk.Attribute{
+			"key":  {Type: types.StringType, Required: true},
+			"name": {Type: types.StringType, Required: true},
+		},
+	}
+	httpResponse := &http.Response{
+		Status:     "400 Bad Request",
+		StatusCode: http.StatusBadRequest,
+		Request:    &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/v1/projects"}},
+	}
+	err := mgmtAPIError{
+		status: "400 Bad Request",
+		body:   []byte(`{"statusCode":400,"message":["key must match /^[a-z0-9-_.]+$/ regular expression","property foo should not exist"],"error":"Bad Request"}`),
+	}
+
+	var diags diag.Diagnostics
+	if !handleDevCycleHTTPForSchema(err, httpResponse, "", schema, &diags) {
+		t.Fatal("expected the request to be reported as failed")
+	}
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d: %v", len(diags), diags)
+	}
+
+	attributeDiag, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok || !attributeDiag.Path().Equal(tftypes.NewAttributePath().WithAttributeName("key")) {
+		t.Fatalf("expected the key error to be attached to the key attribute, got %v", diags[0])
+	}
+	if diags[0].Summary() != "Invalid DevCycle Request" || !strings.Contains(diags[0].Detail(), "POST /v1/projects") {
+		t.Fatalf("unexpected diagnostic: %s: %s", diags[0].Summary(), diags[0].Detail())
+	}
+	if !strings.Contains(diags[1].Detail(), "property foo should not exist") {
+		t.Fatalf("expected the unmatched message in a general diagnostic, got %s", diags[1].Detail())
+	}
+}
+
+func TestHandleDevCycleHTTPNetworkError(t *testing.T) {
+	err := &url.Error{Op: "Get", URL: "https://api.devcycle.com/v1/projects", Err: errors.New("connection refused")}
+
+	var diags diag.Diagnostics
+	if !handleDevCycleHTTP(err, nil, "", &diags) {
+		t.Fatal("expected the request to be reported as failed")
+	}
+	if len(diags) != 1 || diags[0].Summary() != "Unable to reach DevCycle" {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestHandleDevCycleHTTPNotFound(t *testing.T) {
+	httpResponse := &http.Response{
+		Status:     "404 Not Found",
+		StatusCode: http.StatusNotFound,
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/v1/projects/missing"}},
+	}
+	err := mgmtAPIError{status: "404 Not Found", body: []byte(`{"statusCode":404,"message":"Project not found","error":"Not Found"}`)}
+
+	var diags diag.Diagnostics
+	handleDevCycleHTTP(err, httpResponse, "", &diags)
+	if len(diags) != 1 || diags[0].Summary() != "DevCycle Resource Not Found" || !strings.Contains(diags[0].Detail(), "Project not found") {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
+
+func TestHandleDevCycleHTTPUnauthorizedNamesAuthSource(t *testing.T) {
+	httpResponse := &http.Response{
+		Status:     "401 Unauthorized",
+		StatusCode: http.StatusUnauthorized,
+		Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Path: "/v1/projects/project"}},
+	}
+	err := mgmtAPIError{status: "401 Unauthorized", body: []byte(`{"statusCode":401,"message":"Unauthorized"}`)}
+
+	var diags diag.Diagnostics
+	handleDevCycleHTTP(err, httpResponse, "DEVCYCLE_ACCESS_TOKEN environment variable", &diags)
+	if len(diags) != 1 || diags[0].Summary() != "DevCycle Authentication Failed" || !strings.Contains(diags[0].Detail(), "credentials from the DEVCYCLE_ACCESS_TOKEN environment variable") {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+}
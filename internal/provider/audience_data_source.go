@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type audienceDataSourceType struct{}
+
+func (t audienceDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Audience data source.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project to which the audience belongs",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"key": {
+				MarkdownDescription: "Audience key",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				MarkdownDescription: "Audience ID",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"name": {
+				MarkdownDescription: "Audience name",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"description": {
+				MarkdownDescription: "Audience description",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"operator": {
+				MarkdownDescription: "Operator used to combine the audience filters",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"filters": audienceFiltersComputedSchema(),
+		},
+	}, nil
+}
+
+func (t audienceDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return audienceDataSource{
+		provider: provider,
+	}, diags
+}
+
+type audienceDataSource struct {
+	provider provider
+}
+
+func (d audienceDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data audienceResourceData
+	if !d.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var aud audience
+	httpResponse, err := d.provider.mgmtRequest(ctx, http.MethodGet, audiencePath(data.ProjectId.Value, data.Key.Value), nil, &aud)
+	if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+	resp.Diagnostics.Append(data.setFromSDK(aud)...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAudienceDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccAudienceDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.devcycle_audience.test", "id", "devcycle_audience.test", "id"),
+					resource.TestCheckResourceAttr("data.devcycle_audience.test", "operator", "and"),
+					resource.TestCheckResourceAttr("data.devcycle_audience.test", "filters.0.sub_type", "email"),
+				),
+			},
+		},
+	})
+}
+
+var testAccAudienceDataSourceConfig = testAccAudienceResourceConfig + `
+data "devcycle_audience" "test" {
+  project_id = devcycle_audience.test.project_id
+  key = devcycle_audience.test.key
+}
+`
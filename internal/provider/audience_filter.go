@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// audienceFilterJSON mirrors the recursive filter tree used by the management API
+// for targeting audiences. Operator nodes carry `operator` and `filters`, leaf
+// nodes carry the remaining fields.
+type audienceFilterJSON struct {
+	Type        string               `json:"type,omitempty"`
+	SubType     string               `json:"subType,omitempty"`
+	Comparator  string               `json:"comparator,omitempty"`
+	Values      []interface{}        `json:"values,omitempty"`
+	DataKey     string               `json:"dataKey,omitempty"`
+	DataKeyType string               `json:"dataKeyType,omitempty"`
+	Audiences   []string             `json:"_audiences,omitempty"`
+	Operator    string               `json:"operator,omitempty"`
+	Filters     []audienceFilterJSON `json:"filters,omitempty"`
+}
+
+type audienceFilterData struct {
+	Type        types.String `tfsdk:"type"`
+	SubType     types.String `tfsdk:"sub_type"`
+	Comparator  types.String `tfsdk:"comparator"`
+	Values      []string     `tfsdk:"values"`
+	DataKey     types.String `tfsdk:"data_key"`
+	DataKeyType types.String `tfsdk:"data_key_type"`
+	Audiences   []string     `tfsdk:"audiences"`
+}
+
+type audienceFilterGroupData struct {
+	Type        types.String         `tfsdk:"type"`
+	SubType     types.String         `tfsdk:"sub_type"`
+	Comparator  types.String         `tfsdk:"comparator"`
+	Values      []string             `tfsdk:"values"`
+	DataKey     types.String         `tfsdk:"data_key"`
+	DataKeyType types.String         `tfsdk:"data_key_type"`
+	Audiences   []string             `tfsdk:"audiences"`
+	Operator    types.String         `tfsdk:"operator"`
+	Filters     []audienceFilterData `tfsdk:"filters"`
+}
+
+// audienceFilterAttributes returns the attributes of a single audience filter.
+// When computed is set, every attribute is computed, for use in data sources.
+func audienceFilterAttributes(computed bool) map[string]tfsdk.Attribute {
+	return map[string]tfsdk.Attribute{
+		"type": {
+			MarkdownDescription: "Filter type. One of `all`, `user`, `audienceMatch` or `op`. Use `op` to group nested `filters` under their own `operator`.",
+			Required:            !computed,
+			Computed:            computed,
+			Type:                types.StringType,
+		},
+		"sub_type": {
+			MarkdownDescription: "Filter sub type, e.g. `user_id`, `email`, `country`, `platform`, `appVersion` or `customData`",
+			Optional:            !computed,
+			Computed:            computed,
+			Type:                types.StringType,
+		},
+		"comparator": {
+			MarkdownDescription: "Comparator to use, e.g. `=`, `!=`, `>`, `<`, `contain`, `exist`",
+			Optional:            !computed,
+			Computed:            computed,
+			Type:                types.StringType,
+		},
+		"values": {
+			MarkdownDescription: "Values to compare against. Not required when the comparator is `exist` or `!exist`. Values of Number and Boolean custom data filters are converted from their string form.",
+			Optional:            !computed,
+			Computed:            computed,
+			Type:                types.ListType{ElemType: types.StringType},
+		},
+		"data_key": {
+			MarkdownDescription: "Custom data key, for `customData` filters",
+			Optional:            !computed,
+			Computed:            computed,
+			Type:                types.StringType,
+		},
+		"data_key_type": {
+			MarkdownDescription: "Custom data key type, for `customData` filters. One of `String`, `Number` or `Boolean`",
+			Optional:            !computed,
+			Computed:            computed,
+			Type:                types.StringType,
+		},
+		"audiences": {
+			MarkdownDescription: "IDs of the audiences to match, for `audienceMatch` filters. Reference `devcycle_audience` resources to reuse audiences across features.",
+			Optional:            !computed,
+			Computed:            computed,
+			Type:                types.ListType{ElemType: types.StringType},
+		},
+	}
+}
+
+func audienceFiltersAttribute(computed bool) tfsdk.Attribute {
+	groupAttributes := audienceFilterAttributes(computed)
+	groupAttributes["operator"] = tfsdk.Attribute{
+		MarkdownDescription: "Operator used to combine the nested filters of an `op` filter. Either `and` or `or`",
+		Optional:            !computed,
+		Computed:            computed,
+		Type:                types.StringType,
+	}
+	groupAttributes["filters"] = tfsdk.Attribute{
+		MarkdownDescription: "Nested filters of an `op` filter",
+		Optional:            !computed,
+		Computed:            computed,
+		Attributes:          tfsdk.ListNestedAttributes(audienceFilterAttributes(computed), tfsdk.ListNestedAttributesOptions{}),
+	}
+	return tfsdk.Attribute{
+		MarkdownDescription: "Audience filters, combined using the audience operator",
+		Required:            !computed,
+		Computed:            computed,
+		Attributes:          tfsdk.ListNestedAttributes(groupAttributes, tfsdk.ListNestedAttributesOptions{}),
+	}
+}
+
+func audienceFiltersSchema() tfsdk.Attribute {
+	return audienceFiltersAttribute(false)
+}
+
+func audienceFiltersComputedSchema() tfsdk.Attribute {
+	return audienceFiltersAttribute(true)
+}
+
+func audienceFilterValuesToSDK(values []string, dataKeyType string) ([]interface{}, error) {
+	if values == nil {
+		return nil, nil
+	}
+	ret := make([]interface{}, 0, len(values))
+	for _, v := range values {
+		switch dataKeyType {
+		case "Number":
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, fmt.Errorf("value %q is not a valid number", v)
+			}
+			ret = append(ret, f)
+		case "Boolean":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("value %q is not a valid boolean", v)
+			}
+			ret = append(ret, b)
+		default:
+			ret = append(ret, v)
+		}
+	}
+	return ret, nil
+}
+
+// audienceFilterValuesToTF converts filter values to their string form. prior
+// is the previous value of the values, and prior values equal to the returned
+// ones, such as "1.0" for 1, are kept as written. The API leaves out empty
+// lists, so an empty prior list is kept too.
+func audienceFilterValuesToTF(values []interface{}, prior []string) []string {
+	if len(values) == 0 {
+		if prior != nil && len(prior) == 0 {
+			return []string{}
+		}
+		return nil
+	}
+	ret := make([]string, 0, len(values))
+	for i, v := range values {
+		var value string
+		switch v := v.(type) {
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+			if i < len(prior) {
+				if f, err := strconv.ParseFloat(prior[i], 64); err == nil && f == v {
+					value = prior[i]
+				}
+			}
+		case bool:
+			value = strconv.FormatBool(v)
+			if i < len(prior) {
+				if b, err := strconv.ParseBool(prior[i]); err == nil && b == v {
+					value = prior[i]
+				}
+			}
+		default:
+			value = fmt.Sprintf("%v", v)
+		}
+		ret = append(ret, value)
+	}
+	return ret
+}
+
+func (f audienceFilterData) toSDK() (audienceFilterJSON, error) {
+	values, err := audienceFilterValuesToSDK(f.Values, f.DataKeyType.Value)
+	if err != nil {
+		return audienceFilterJSON{}, err
+	}
+	return audienceFilterJSON{
+		Type:        f.Type.Value,
+		SubType:     f.SubType.Value,
+		Comparator:  f.Comparator.Value,
+		Values:      values,
+		DataKey:     f.DataKey.Value,
+		DataKeyType: f.DataKeyType.Value,
+		Audiences:   f.Audiences,
+	}, nil
+}
+
+func (f audienceFilterGroupData) toSDK() (audienceFilterJSON, error) {
+	ret, err := audienceFilterData{
+		Type:        f.Type,
+		SubType:     f.SubType,
+		Comparator:  f.Comparator,
+		Values:      f.Values,
+		DataKey:     f.DataKey,
+		DataKeyType: f.DataKeyType,
+		Audiences:   f.Audiences,
+	}.toSDK()
+	if err != nil {
+		return ret, err
+	}
+	ret.Operator = f.Operator.Value
+	for _, nested := range f.Filters {
+		filter, err := nested.toSDK()
+		if err != nil {
+			return ret, err
+		}
+		ret.Filters = append(ret.Filters, filter)
+	}
+	return ret, nil
+}
+
+// audienceFiltersToSDK builds the API filter tree for an audience from its
+// Terraform operator and filters.
+func audienceFiltersToSDK(operator string, filters []audienceFilterGroupData) (audienceFilterJSON, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ret := audienceFilterJSON{
+		Operator: operator,
+		Filters:  []audienceFilterJSON{},
+	}
+	for i, f := range filters {
+		filter, err := f.toSDK()
+		if err != nil {
+			diags.AddError("Invalid Audience Filter", fmt.Sprintf("Audience filter %d is invalid: %s", i, err))
+			continue
+		}
+		ret.Filters = append(ret.Filters, filter)
+	}
+	return ret, diags
+}
+
+// audienceFilterToTF converts a leaf filter. prior is the previous value of the
+// filter, used to keep the configured form of its lists.
+func audienceFilterToTF(f audienceFilterJSON, prior audienceFilterData) audienceFilterData {
+	var audiences []string
+	if len(f.Audiences) > 0 {
+		audiences = f.Audiences
+	} else if prior.Audiences != nil && len(prior.Audiences) == 0 {
+		audiences = []string{}
+	}
+	return audienceFilterData{
+		Type:        types.String{Value: f.Type},
+		SubType:     optionalString(f.SubType),
+		Comparator:  optionalString(f.Comparator),
+		Values:      audienceFilterValuesToTF(f.Values, prior.Values),
+		DataKey:     optionalString(f.DataKey),
+		DataKeyType: optionalString(f.DataKeyType),
+		Audiences:   audiences,
+	}
+}
+
+// audienceFiltersToTF converts the untyped filter tree returned by the
+// management API into the Terraform operator and filters. prior is the previous
+// value of the filters, used to keep their configured form.
+func audienceFiltersToTF(raw interface{}, prior []audienceFilterGroupData) (types.String, []audienceFilterGroupData, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var root audienceFilterJSON
+
+	marshalled, err := json.Marshal(raw)
+	if err == nil {
+		err = json.Unmarshal(marshalled, &root)
+	}
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to parse audience filters, got error: %s", err))
+		return types.String{Null: true}, nil, diags
+	}
+
+	var filters []audienceFilterGroupData
+	if prior != nil && len(prior) == 0 {
+		filters = []audienceFilterGroupData{}
+	}
+	for i, f := range root.Filters {
+		var priorGroup audienceFilterGroupData
+		if i < len(prior) {
+			priorGroup = prior[i]
+		}
+		leaf := audienceFilterToTF(f, audienceFilterData{Values: priorGroup.Values, Audiences: priorGroup.Audiences})
+		group := audienceFilterGroupData{
+			Type:        leaf.Type,
+			SubType:     leaf.SubType,
+			Comparator:  leaf.Comparator,
+			Values:      leaf.Values,
+			DataKey:     leaf.DataKey,
+			DataKeyType: leaf.DataKeyType,
+			Audiences:   leaf.Audiences,
+			Operator:    optionalString(f.Operator),
+		}
+		if priorGroup.Filters != nil && len(priorGroup.Filters) == 0 {
+			group.Filters = []audienceFilterData{}
+		}
+		for j, nested := range f.Filters {
+			// The schema holds two levels of filters, and ignoring deeper
+			// groups would drop their conditions on the next apply.
+			if len(nested.Filters) > 0 {
+				diags.AddError(
+					"Unsupported Audience Filters",
+					fmt.Sprintf("Audience filter %d has a nested filter %d grouping filters of its own. Filters nested more than two levels deep cannot be represented in Terraform, so reading them would drop conditions. Flatten the filters in DevCycle to manage them with Terraform.", i, j),
+				)
+				continue
+			}
+			var priorNested audienceFilterData
+			if j < len(priorGroup.Filters) {
+				priorNested = priorGroup.Filters[j]
+			}
+			group.Filters = append(group.Filters, audienceFilterToTF(nested, priorNested))
+		}
+		filters = append(filters, group)
+	}
+	return types.String{Value: root.Operator}, filters, diags
+}
@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// audience is the management API representation of a project audience.
+type audience struct {
+	Id          string             `json:"_id,omitempty"`
+	Project     string             `json:"_project,omitempty"`
+	Key         string             `json:"key"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Filters     audienceFilterJSON `json:"filters"`
+}
+
+func audiencesPath(project string) string {
+	return fmt.Sprintf("/v1/projects/%s/audiences", url.PathEscape(project))
+}
+
+func audiencePath(project, audience string) string {
+	return audiencesPath(project) + "/" + url.PathEscape(audience)
+}
+
+type audienceResourceType struct{}
+
+func (t audienceResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Audience resource. Audiences are reusable sets of filters that can be matched in feature targeting with an `audienceMatch` filter.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project to which the audience belongs",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"key": {
+				MarkdownDescription: "Audience key, usually the lowercase, kebab case name of the audience",
+				Required:            true,
+				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{keyValidator{}},
+			},
+			"name": {
+				MarkdownDescription: "Audience name",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"description": {
+				MarkdownDescription: "Audience description",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"operator": {
+				MarkdownDescription: "Operator used to combine the audience filters. Either `and` or `or`",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"filters": audienceFiltersSchema(),
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Audience ID",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t audienceResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return audienceResource{
+		provider: provider,
+	}, diags
+}
+
+type audienceResourceData struct {
+	Id          types.String              `tfsdk:"id"`
+	ProjectId   types.String              `tfsdk:"project_id"`
+	Key         types.String              `tfsdk:"key"`
+	Name        types.String              `tfsdk:"name"`
+	Description types.String              `tfsdk:"description"`
+	Operator    types.String              `tfsdk:"operator"`
+	Filters     []audienceFilterGroupData `tfsdk:"filters"`
+}
+
+func (a audienceResourceData) toSDK() (audience, diag.Diagnostics) {
+	filters, diags := audienceFiltersToSDK(a.Operator.Value, a.Filters)
+	return audience{
+		Key:         a.Key.Value,
+		Name:        a.Name.Value,
+		Description: a.Description.Value,
+		Filters:     filters,
+	}, diags
+}
+
+func (a *audienceResourceData) setFromSDK(aud audience) diag.Diagnostics {
+	operator, filters, diags := audienceFiltersToTF(aud.Filters, a.Filters)
+	a.Id = types.String{Value: aud.Id}
+	a.Key = types.String{Value: aud.Key}
+	a.Name = types.String{Value: aud.Name}
+	a.Description = optionalString(aud.Description)
+	a.Operator = operator
+	a.Filters = filters
+	return diags
+}
+
+type audienceResource struct {
+	provider provider
+}
+
+func (r audienceResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data audienceResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.toSDK()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var aud audience
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodPost, audiencesPath(data.ProjectId.Value), body, &aud)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+	resp.Diagnostics.Append(data.setFromSDK(aud)...)
+
+	tflog.Trace(ctx, "created an audience", "id", data.Id.Value)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r audienceResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data audienceResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var aud audience
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodGet, audiencePath(data.ProjectId.Value, data.Id.Value), nil, &aud)
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "audience no longer exists in DevCycle, removing it from state", "project_id", data.ProjectId.Value, "id", data.Id.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+	resp.Diagnostics.Append(data.setFromSDK(aud)...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r audienceResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data audienceResourceData
+	var state audienceResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.toSDK()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var aud audience
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodPatch, audiencePath(data.ProjectId.Value, state.Id.Value), body, &aud)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+	resp.Diagnostics.Append(data.setFromSDK(aud)...)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r audienceResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data audienceResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodDelete, audiencePath(data.ProjectId.Value, data.Id.Value), nil, nil)
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r audienceResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_key/audience_key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	// The audience key is resolved to its ID by the Read that follows the import.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), parts[1])...)
+}
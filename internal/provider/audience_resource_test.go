@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAudienceResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAudienceResourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_audience.test", "key", "terraform-acceptance-testing"+randString),
+					resource.TestCheckResourceAttr("devcycle_audience.test", "filters.#", "1"),
+					resource.TestCheckResourceAttrSet("devcycle_audience.test", "id"),
+				),
+			},
+			{
+				Config: testAccAudienceResourceConfigEdit,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_audience.test", "description", "Terraform acceptance testing edited"),
+					resource.TestCheckResourceAttr("devcycle_audience.test", "filters.#", "2"),
+					resource.TestCheckResourceAttr("devcycle_audience.test", "filters.1.filters.#", "2"),
+				),
+			},
+			{
+				ResourceName:        "devcycle_audience.test",
+				ImportState:         true,
+				ImportStateVerify:   true,
+				ImportStateIdPrefix: "622112634cabe0e9fbaf974d/",
+			},
+			{
+				Config:  testAccAudienceResourceConfigEdit,
+				Destroy: true,
+			},
+		},
+	})
+}
+
+var testAccAudienceResourceConfig = `
+resource "devcycle_audience" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key = "terraform-acceptance-testing` + randString + `"
+  name = "TerraformAccTest` + randString + `"
+  description = "Terraform acceptance testing"
+  operator = "and"
+  filters = [
+	{
+	  type = "user"
+	  sub_type = "email"
+	  comparator = "contain"
+	  values = ["@devcycle.com"]
+	}
+  ]
+}
+`
+
+var testAccAudienceResourceConfigEdit = `
+resource "devcycle_audience" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key = "terraform-acceptance-testing` + randString + `"
+  name = "TerraformAccTest` + randString + `"
+  description = "Terraform acceptance testing edited"
+  operator = "or"
+  filters = [
+	{
+	  type = "user"
+	  sub_type = "email"
+	  comparator = "contain"
+	  values = ["@devcycle.com"]
+	},
+	{
+	  type = "op"
+	  operator = "and"
+	  filters = [
+		{
+		  type = "user"
+		  sub_type = "customData"
+		  data_key = "beta"
+		  data_key_type = "Boolean"
+		  comparator = "="
+		  values = ["true"]
+		},
+		{
+		  type = "user"
+		  sub_type = "country"
+		  comparator = "="
+		  values = ["CA"]
+		}
+	  ]
+	}
+  ]
+}
+`
+
+func TestAudienceFiltersToTFDepth(t *testing.T) {
+	leaf := map[string]interface{}{"type": "user", "subType": "email", "comparator": "=", "values": []interface{}{"a@example.com"}}
+	group := func(filters ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"type": "op", "operator": "or", "filters": filters}
+	}
+
+	operator, filters, diags := audienceFiltersToTF(group(group(leaf, leaf)), nil)
+	if diags.HasError() || operator.Value != "or" || len(filters) != 1 || len(filters[0].Filters) != 2 {
+		t.Fatalf("expected two levels of filters to be read, got %v %+v %v", operator, filters, diags)
+	}
+
+	_, _, diags = audienceFiltersToTF(group(group(group(leaf))), nil)
+	if !diags.HasError() || diags[0].Summary() != "Unsupported Audience Filters" {
+		t.Fatalf("expected deeper filters to be reported, got %v", diags)
+	}
+}
+
+func TestAudienceFiltersToTFKeepsPriorForm(t *testing.T) {
+	raw := map[string]interface{}{
+		"operator": "and",
+		"filters": []interface{}{
+			map[string]interface{}{"type": "user", "subType": "customData", "comparator": "=", "dataKey": "plan", "dataKeyType": "Number", "values": []interface{}{float64(1), float64(2)}},
+			map[string]interface{}{"type": "user", "subType": "email", "comparator": "exist"},
+		},
+	}
+	prior := []audienceFilterGroupData{
+		{Values: []string{"1.0", "3"}},
+		{Values: []string{}, Audiences: []string{}},
+	}
+
+	_, filters, diags := audienceFiltersToTF(raw, prior)
+	if diags.HasError() || len(filters) != 2 {
+		t.Fatalf("unexpected filters %+v: %v", filters, diags)
+	}
+	if values := filters[0].Values; len(values) != 2 || values[0] != "1.0" || values[1] != "2" {
+		t.Errorf("expected the equal prior value to be kept and the changed one replaced, got %v", values)
+	}
+	if filters[1].Values == nil || len(filters[1].Values) != 0 || filters[1].Audiences == nil {
+		t.Errorf("expected empty prior lists to be kept, got %+v", filters[1])
+	}
+
+	_, filters, _ = audienceFiltersToTF(raw, nil)
+	if filters[0].Values[0] != "1" || filters[1].Values != nil {
+		t.Errorf("expected normalized values without a prior value, got %+v", filters)
+	}
+}
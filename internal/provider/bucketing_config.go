@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+)
+
+// serveBucketingConfigFile serves a recorded project config from disk on a
+// local listener, so the server SDK's local bucketing can load it in place of
+// the config CDN. The caller owns the returned listener and closes it to stop
+// serving; "http://" + listener.Addr().String() is the SDK's ConfigCDNURI.
+func serveBucketingConfigFile(path string) (net.Listener, error) {
+	config, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !json.Valid(config) {
+		return nil, fmt.Errorf("%s does not contain valid JSON", path)
+	}
+	etag := fmt.Sprintf("%q", fmt.Sprintf("%x", sha256.Sum256(config)))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write(config)
+	})}
+	// Without keep-alive connections, closing the listener stops serving.
+	server.SetKeepAlivesEnabled(false)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	return listener, nil
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestServeBucketingConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := `{"project": {"key": "project"}, "features": [], "variables": []}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	listener, err := serveBucketingConfigFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	url := "http://" + listener.Addr().String() + "/config/v1/server/token.json"
+
+	res, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || string(body) != config {
+		t.Fatalf("expected the config file, got %d %s", res.StatusCode, body)
+	}
+	etag := res.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("expected an ETag header")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("If-None-Match", etag)
+	res, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotModified {
+		t.Errorf("expected %d for a matching ETag, got %d", http.StatusNotModified, res.StatusCode)
+	}
+
+	if err := listener.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := http.Get(url); err == nil {
+		t.Error("expected the config to no longer be served once the listener is closed")
+	}
+}
+
+func TestServeBucketingConfigFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if listener, err := serveBucketingConfigFile(path); err == nil {
+		listener.Close()
+		t.Fatal("expected invalid JSON to be rejected")
+	}
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const customPropertiesPageSize = 100
+
+type customPropertiesDataSourceType struct{}
+
+func (t customPropertiesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Custom Properties data source. Lists the custom properties of a project.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project to list custom properties of",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"search": {
+				MarkdownDescription: "Only list custom properties matching this search term",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"custom_properties": {
+				MarkdownDescription: "Custom properties of the project",
+				Computed:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"id": {
+						MarkdownDescription: "Custom property ID",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"key": {
+						MarkdownDescription: "Custom property key",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"property_key": {
+						MarkdownDescription: "Key of the property in the custom data sent by the SDKs",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"name": {
+						MarkdownDescription: "Custom property display name",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"type": {
+						MarkdownDescription: "Custom property type",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
+		},
+	}, nil
+}
+
+func (t customPropertiesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return customPropertiesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type customPropertiesDataSourceData struct {
+	ProjectId        types.String                             `tfsdk:"project_id"`
+	Search           types.String                             `tfsdk:"search"`
+	CustomProperties []customPropertiesDataSourceDataProperty `tfsdk:"custom_properties"`
+}
+
+type customPropertiesDataSourceDataProperty struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	PropertyKey types.String `tfsdk:"property_key"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+}
+
+type customPropertiesDataSource struct {
+	provider provider
+}
+
+func (d customPropertiesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data customPropertiesDataSourceData
+	if !d.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := &devcyclem.CustomPropertiesApiCustomPropertiesControllerFindAllOpts{
+		PerPage: optional.NewFloat64(customPropertiesPageSize),
+	}
+	if data.Search.Value != "" {
+		opts.Search = optional.NewString(data.Search.Value)
+	}
+
+	data.CustomProperties = []customPropertiesDataSourceDataProperty{}
+	for page := 1; ; page++ {
+		opts.Page = optional.NewFloat64(float64(page))
+		properties, httpResponse, err := d.provider.MgmtClient.CustomPropertiesApi.CustomPropertiesControllerFindAll(ctx, data.ProjectId.Value, opts)
+		if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
+			return
+		}
+		for _, property := range properties {
+			data.CustomProperties = append(data.CustomProperties, customPropertiesDataSourceDataProperty{
+				Id:          types.String{Value: property.Id},
+				Key:         types.String{Value: property.Key},
+				PropertyKey: types.String{Value: property.PropertyKey},
+				Name:        types.String{Value: property.Name},
+				Type:        types.String{Value: property.Type_},
+			})
+		}
+		if len(properties) < customPropertiesPageSize {
+			break
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
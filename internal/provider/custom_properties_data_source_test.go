@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCustomPropertiesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccCustomPropertiesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_custom_properties.test", "custom_properties.#", "1"),
+					resource.TestCheckResourceAttrPair("data.devcycle_custom_properties.test", "custom_properties.0.id", "devcycle_custom_property.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+var testAccCustomPropertiesDataSourceConfig = testAccCustomPropertyResourceConfig + `
+data "devcycle_custom_properties" "test" {
+  project_id = devcycle_custom_property.test.project_id
+  search = devcycle_custom_property.test.key
+}
+`
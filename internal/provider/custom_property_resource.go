@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// customProperty is the management API representation of a custom property,
+// including the optional schema that the generated client does not model.
+type customProperty struct {
+	Id          string                `json:"_id,omitempty"`
+	Key         string                `json:"key,omitempty"`
+	Name        string                `json:"name"`
+	PropertyKey string                `json:"propertyKey"`
+	Type_       string                `json:"type,omitempty"`
+	Schema      *customPropertySchema `json:"schema,omitempty"`
+}
+
+type customPropertySchema struct {
+	SchemaType string                    `json:"schemaType"`
+	Required   bool                      `json:"required"`
+	EnumSchema *customPropertyEnumSchema `json:"enumSchema,omitempty"`
+}
+
+type customPropertyEnumSchema struct {
+	AllowedValues         []customPropertyEnumValue `json:"allowedValues"`
+	AllowAdditionalValues bool                      `json:"allowAdditionalValues"`
+}
+
+type customPropertyEnumValue struct {
+	Label string      `json:"label"`
+	Value interface{} `json:"value"`
+}
+
+func customPropertiesPath(project string) string {
+	return fmt.Sprintf("/v1/projects/%s/customProperties", url.PathEscape(project))
+}
+
+func customPropertyPath(project, key string) string {
+	return customPropertiesPath(project) + "/" + url.PathEscape(key)
+}
+
+type customPropertyResourceType struct{}
+
+func (t customPropertyResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Custom Property resource. Custom properties must exist in a project before custom data can be used in targeting.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project to which the custom property belongs",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"key": {
+				MarkdownDescription: "Custom property key, used to reference the custom property in the management API. Must only contain lower-case characters and `_` or `-`.",
+				Required:            true,
+				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{keyValidator{}},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"property_key": {
+				MarkdownDescription: "Key of the property in the custom data sent by the SDKs",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"name": {
+				MarkdownDescription: "Custom property display name",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"type": {
+				MarkdownDescription: "Custom property type. One of " + oneOfMarkdown(customPropertyTypeValues),
+				Required:            true,
+				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{oneOf(customPropertyTypeValues...)},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"schema": {
+				MarkdownDescription: "Custom property schema, restricting the values the property can take",
+				Optional:            true,
+				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+					"required": {
+						MarkdownDescription: "Whether the property is required",
+						Optional:            true,
+						Type:                types.BoolType,
+					},
+					"enum_values": {
+						MarkdownDescription: "Allowed values of the property",
+						Optional:            true,
+						Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+							"label": {
+								MarkdownDescription: "Label displayed for the value",
+								Optional:            true,
+								Type:                types.StringType,
+							},
+							"value": {
+								MarkdownDescription: "Allowed value. Values of Number properties are converted from their string form.",
+								Required:            true,
+								Type:                types.StringType,
+							},
+						}, tfsdk.ListNestedAttributesOptions{}),
+					},
+					"allow_additional_values": {
+						MarkdownDescription: "Whether values other than `enum_values` are allowed",
+						Optional:            true,
+						Type:                types.BoolType,
+					},
+				}),
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Custom property ID",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t customPropertyResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return customPropertyResource{
+		provider: provider,
+	}, diags
+}
+
+type customPropertyResourceData struct {
+	Id          types.String                      `tfsdk:"id"`
+	ProjectId   types.String                      `tfsdk:"project_id"`
+	Key         types.String                      `tfsdk:"key"`
+	PropertyKey types.String                      `tfsdk:"property_key"`
+	Name        types.String                      `tfsdk:"name"`
+	Type        types.String                      `tfsdk:"type"`
+	Schema      *customPropertyResourceDataSchema `tfsdk:"schema"`
+}
+
+type customPropertyResourceDataSchema struct {
+	Required              types.Bool                            `tfsdk:"required"`
+	EnumValues            []customPropertyResourceDataEnumValue `tfsdk:"enum_values"`
+	AllowAdditionalValues types.Bool                            `tfsdk:"allow_additional_values"`
+}
+
+type customPropertyResourceDataEnumValue struct {
+	Label types.String `tfsdk:"label"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (c customPropertyResourceData) toSDK() (customProperty, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ret := customProperty{
+		Key:         c.Key.Value,
+		Name:        c.Name.Value,
+		PropertyKey: c.PropertyKey.Value,
+		Type_:       c.Type.Value,
+		Schema:      &customPropertySchema{SchemaType: "none"},
+	}
+	if c.Schema == nil {
+		return ret, diags
+	}
+
+	ret.Schema.Required = c.Schema.Required.Value
+	if c.Schema.EnumValues != nil {
+		ret.Schema.SchemaType = "enum"
+		ret.Schema.EnumSchema = &customPropertyEnumSchema{
+			AllowedValues:         []customPropertyEnumValue{},
+			AllowAdditionalValues: c.Schema.AllowAdditionalValues.Value,
+		}
+		for i, enumValue := range c.Schema.EnumValues {
+			var value interface{} = enumValue.Value.Value
+			if c.Type.Value == "Number" {
+				f, err := strconv.ParseFloat(enumValue.Value.Value, 64)
+				if err != nil {
+					diags.AddAttributeError(
+						tftypes.NewAttributePath().WithAttributeName("schema").WithAttributeName("enum_values").WithElementKeyInt(i).WithAttributeName("value"),
+						"Invalid Enum Value",
+						fmt.Sprintf("Value %q of a Number custom property is not a valid number.", enumValue.Value.Value),
+					)
+					continue
+				}
+				value = f
+			}
+			ret.Schema.EnumSchema.AllowedValues = append(ret.Schema.EnumSchema.AllowedValues, customPropertyEnumValue{
+				Label: enumValue.Label.Value,
+				Value: value,
+			})
+		}
+	}
+	return ret, diags
+}
+
+// setFromSDK updates the data from the API response. Optional schema flags that
+// are unset in Terraform stay null while the API reports their default.
+func (c *customPropertyResourceData) setFromSDK(property customProperty) {
+	c.Id = types.String{Value: property.Id}
+	c.Key = types.String{Value: property.Key}
+	c.PropertyKey = types.String{Value: property.PropertyKey}
+	c.Name = types.String{Value: property.Name}
+	c.Type = types.String{Value: property.Type_}
+
+	if property.Schema == nil || (property.Schema.SchemaType != "enum" && !property.Schema.Required) {
+		if c.Schema != nil && c.Schema.EnumValues == nil && !c.Schema.Required.Value {
+			return
+		}
+		c.Schema = nil
+		return
+	}
+
+	prior := c.Schema
+	if prior == nil {
+		prior = &customPropertyResourceDataSchema{
+			Required:              types.Bool{Null: true},
+			AllowAdditionalValues: types.Bool{Null: true},
+		}
+	}
+	schema := &customPropertyResourceDataSchema{
+		Required:              optionalBool(prior.Required, property.Schema.Required),
+		AllowAdditionalValues: types.Bool{Null: true},
+	}
+	if property.Schema.EnumSchema != nil {
+		schema.AllowAdditionalValues = optionalBool(prior.AllowAdditionalValues, property.Schema.EnumSchema.AllowAdditionalValues)
+		schema.EnumValues = []customPropertyResourceDataEnumValue{}
+		for _, enumValue := range property.Schema.EnumSchema.AllowedValues {
+			schema.EnumValues = append(schema.EnumValues, customPropertyResourceDataEnumValue{
+				Label: optionalString(enumValue.Label),
+				Value: types.String{Value: audienceFilterValuesToTF([]interface{}{enumValue.Value}, nil)[0]},
+			})
+		}
+	}
+	c.Schema = schema
+}
+
+type customPropertyResource struct {
+	provider provider
+}
+
+func (r customPropertyResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data customPropertyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.toSDK()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var property customProperty
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodPost, customPropertiesPath(data.ProjectId.Value), body, &property)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+	data.setFromSDK(property)
+
+	tflog.Trace(ctx, "created a custom property", "id", data.Id.Value)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r customPropertyResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data customPropertyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var property customProperty
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodGet, customPropertyPath(data.ProjectId.Value, data.Key.Value), nil, &property)
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "custom property no longer exists in DevCycle, removing it from state", "project_id", data.ProjectId.Value, "key", data.Key.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+	data.setFromSDK(property)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r customPropertyResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data customPropertyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.toSDK()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// The key and type of a custom property cannot be updated.
+	body.Key = ""
+	body.Type_ = ""
+
+	var property customProperty
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodPatch, customPropertyPath(data.ProjectId.Value, data.Key.Value), body, &property)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+	data.setFromSDK(property)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r customPropertyResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data customPropertyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodDelete, customPropertyPath(data.ProjectId.Value, data.Key.Value), nil, nil)
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r customPropertyResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_key/custom_property_key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("key"), parts[1])...)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCustomPropertyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCustomPropertyResourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_custom_property.test", "type", "String"),
+					resource.TestCheckResourceAttrSet("devcycle_custom_property.test", "id"),
+				),
+			},
+			{
+				Config: testAccCustomPropertyResourceConfigEdit,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_custom_property.test", "name", "TerraformAccTest"+randString+" edited"),
+					resource.TestCheckResourceAttr("devcycle_custom_property.test", "schema.enum_values.#", "2"),
+				),
+			},
+			{
+				ResourceName:      "devcycle_custom_property.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     "622112634cabe0e9fbaf974d/terraform-acceptance-testing" + randString,
+			},
+			{
+				Config:  testAccCustomPropertyResourceConfigEdit,
+				Destroy: true,
+			},
+		},
+	})
+}
+
+var testAccCustomPropertyResourceConfig = `
+resource "devcycle_custom_property" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key = "terraform-acceptance-testing` + randString + `"
+  property_key = "terraformAccTest` + randString + `"
+  name = "TerraformAccTest` + randString + `"
+  type = "String"
+}
+`
+
+var testAccCustomPropertyResourceConfigEdit = `
+resource "devcycle_custom_property" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key = "terraform-acceptance-testing` + randString + `"
+  property_key = "terraformAccTest` + randString + `"
+  name = "TerraformAccTest` + randString + ` edited"
+  type = "String"
+  schema = {
+	required = true
+	enum_values = [
+	  {
+		label = "Free"
+		value = "free"
+	  },
+	  {
+		label = "Enterprise"
+		value = "enterprise"
+	  }
+	]
+	allow_additional_values = false
+  }
+}
+`
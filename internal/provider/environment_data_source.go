@@ -62,6 +62,16 @@ func (t environmentDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema,
 				Computed:            true,
 				MarkdownDescription: "SDK Keys for the environment",
 				Type:                types.ListType{ElemType: types.StringType},
+				Sensitive:           true,
+				DeprecationMessage:  "Use server_sdk_keys, client_sdk_keys and mobile_sdk_keys instead.",
+			},
+			"server_sdk_keys": sdkKeysAttribute("Server"),
+			"client_sdk_keys": sdkKeysAttribute("Client"),
+			"mobile_sdk_keys": sdkKeysAttribute("Mobile"),
+			"store_server_sdk_keys": {
+				MarkdownDescription: "Whether to store the server SDK keys in the Terraform state. When `false`, `server_sdk_keys` is null and `sdk_keys` only lists client and mobile keys. Defaults to `true`.",
+				Optional:            true,
+				Type:                types.BoolType,
 			},
 		},
 	}, nil
@@ -76,15 +86,20 @@ func (t environmentDataSourceType) NewDataSource(ctx context.Context, in tfsdk.P
 }
 
 type environmentDataSourceData struct {
-	Id          types.String `tfsdk:"id"`
-	Key         types.String `tfsdk:"key"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Color       types.String `tfsdk:"color"`
-	Type        types.String `tfsdk:"type"`
-	ProjectId   types.String `tfsdk:"project_id"`
-	ProjectKey  types.String `tfsdk:"project_key"`
-	SDKKeys     []string     `tfsdk:"sdk_keys"`
+	Id            types.String `tfsdk:"id"`
+	Key           types.String `tfsdk:"key"`
+	Name          types.String `tfsdk:"name"`
+	Description   types.String `tfsdk:"description"`
+	Color         types.String `tfsdk:"color"`
+	Type          types.String `tfsdk:"type"`
+	ProjectId     types.String `tfsdk:"project_id"`
+	ProjectKey    types.String `tfsdk:"project_key"`
+	SDKKeys       types.List   `tfsdk:"sdk_keys"`
+	ServerSDKKeys types.List   `tfsdk:"server_sdk_keys"`
+	ClientSDKKeys types.List   `tfsdk:"client_sdk_keys"`
+	MobileSDKKeys types.List   `tfsdk:"mobile_sdk_keys"`
+
+	StoreServerSDKKeys types.Bool `tfsdk:"store_server_sdk_keys"`
 }
 
 type environmentDataSource struct {
@@ -106,8 +121,8 @@ func (d environmentDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourc
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	environment, httpResponse, err := d.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, data.Key.Value, data.ProjectKey.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	environment, httpResponse, err := d.provider.getEnvironment(ctx, data.ProjectKey.Value, data.Key.Value)
+	if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -118,9 +133,8 @@ func (d environmentDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourc
 	data.Color = types.String{Value: environment.Color}
 	data.Type = types.String{Value: environment.Type_}
 	data.ProjectId = types.String{Value: environment.Project}
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	storeServerKeys := data.StoreServerSDKKeys.Null || data.StoreServerSDKKeys.Value
+	data.SDKKeys, data.ServerSDKKeys, data.ClientSDKKeys, data.MobileSDKKeys = environmentSDKKeys(environment.SdkKeys, storeServerKeys)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
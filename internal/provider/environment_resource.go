@@ -2,8 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"net/http"
+	"reflect"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -33,6 +39,7 @@ func (t environmentResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, d
 				MarkdownDescription: "Environment Key",
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{keyValidator{}},
 			},
 			"description": {
 				MarkdownDescription: "Environment Description",
@@ -43,20 +50,40 @@ func (t environmentResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, d
 				MarkdownDescription: "Environment Color in Hex with leading #",
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{hexColorValidator{}},
 			},
 			"type": {
-				MarkdownDescription: "Environment Type",
+				MarkdownDescription: "Environment Type. One of " + oneOfMarkdown(environmentTypeValues),
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{oneOf(environmentTypeValues...)},
 			},
 			"settings": {
-				MarkdownDescription: "Environment Settings",
-				Required:            true,
+				MarkdownDescription: "Environment Settings. Settings that are not configured keep the value set in DevCycle.",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
 				Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
 					"app_icon_uri": {
 						MarkdownDescription: "Environment App Icon Uri",
-						Required:            true,
+						Optional:            true,
+						Computed:            true,
 						Type:                types.StringType,
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.UseStateForUnknown(),
+						},
+					},
+					"additional": {
+						MarkdownDescription: "Other environment settings, keyed by their name in the management API, with JSON encoded values. Once configured, only the listed settings are managed, so that settings added to the API later do not cause changes.",
+						Optional:            true,
+						Computed:            true,
+						Type:                types.MapType{ElemType: types.StringType},
+						Validators:          []tfsdk.AttributeValidator{jsonValuesValidator{}},
+						PlanModifiers: tfsdk.AttributePlanModifiers{
+							tfsdk.UseStateForUnknown(),
+						},
 					},
 				}),
 			},
@@ -72,6 +99,17 @@ func (t environmentResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, d
 				Computed:            true,
 				MarkdownDescription: "SDK Keys for the environment",
 				Type:                types.ListType{ElemType: types.StringType},
+				Sensitive:           true,
+				DeprecationMessage:  "Use server_sdk_keys, client_sdk_keys and mobile_sdk_keys instead.",
+			},
+			"server_sdk_keys":     sdkKeysAttribute("Server"),
+			"client_sdk_keys":     sdkKeysAttribute("Client"),
+			"mobile_sdk_keys":     sdkKeysAttribute("Mobile"),
+			"deletion_protection": deletionProtectionAttribute("environment"),
+			"store_server_sdk_keys": {
+				MarkdownDescription: "Whether to store the server SDK keys in the Terraform state. When `false`, `server_sdk_keys` is null and `sdk_keys` only lists client and mobile keys, and the provider can fetch a server key when needed with its `server_sdk_environment` setting. Defaults to `true`.",
+				Optional:            true,
+				Type:                types.BoolType,
 			},
 		},
 	}, nil
@@ -86,38 +124,139 @@ func (t environmentResourceType) NewResource(ctx context.Context, in tfsdk.Provi
 }
 
 type environmentResourceData struct {
-	Id          types.String                    `tfsdk:"id"`
-	Key         types.String                    `tfsdk:"key"`
-	Name        types.String                    `tfsdk:"name"`
-	Description types.String                    `tfsdk:"description"`
-	Color       types.String                    `tfsdk:"color"`
-	Type        types.String                    `tfsdk:"type"`
-	Settings    environmentResourceDataSettings `tfsdk:"settings"`
-	ProjectId   types.String                    `tfsdk:"project_id"`
-	SDKKeys     []string                        `tfsdk:"sdk_keys"`
+	Id            types.String                     `tfsdk:"id"`
+	Key           types.String                     `tfsdk:"key"`
+	Name          types.String                     `tfsdk:"name"`
+	Description   types.String                     `tfsdk:"description"`
+	Color         types.String                     `tfsdk:"color"`
+	Type          types.String                     `tfsdk:"type"`
+	Settings      *environmentResourceDataSettings `tfsdk:"settings"`
+	ProjectId     types.String                     `tfsdk:"project_id"`
+	SDKKeys       types.List                       `tfsdk:"sdk_keys"`
+	ServerSDKKeys types.List                       `tfsdk:"server_sdk_keys"`
+	ClientSDKKeys types.List                       `tfsdk:"client_sdk_keys"`
+	MobileSDKKeys types.List                       `tfsdk:"mobile_sdk_keys"`
+
+	StoreServerSDKKeys types.Bool `tfsdk:"store_server_sdk_keys"`
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
 }
 
-func sdkKeyConvert(keys []devcyclem.ApiKey) []string {
-	var sdkKeys []string
-	for _, sdkKey := range keys {
-		sdkKeys = append(sdkKeys, sdkKey.Key)
-	}
-	return sdkKeys
+func (d *environmentResourceData) fromSDK(environment environment) {
+	d.Id = types.String{Value: environment.Id}
+	d.Key = types.String{Value: environment.Key}
+	d.Name = types.String{Value: environment.Name}
+	d.Description = types.String{Value: environment.Description}
+	d.Color = types.String{Value: environment.Color}
+	d.Type = types.String{Value: environment.Type_}
+	d.Settings = settingsFromSDK(environment.Settings, d.Settings)
+	d.ProjectId = types.String{Value: environment.Project}
+	storeServerKeys := d.StoreServerSDKKeys.Null || d.StoreServerSDKKeys.Value
+	d.SDKKeys, d.ServerSDKKeys, d.ClientSDKKeys, d.MobileSDKKeys = environmentSDKKeys(environment.SdkKeys, storeServerKeys)
 }
 
+// environmentResourceDataSettings holds the environment settings. Settings are
+// optional and computed, so unset settings keep their value in DevCycle. The
+// generated client only models the app icon URI, so every other setting is
+// kept in Additional as JSON.
 type environmentResourceDataSettings struct {
 	AppIconURI types.String `tfsdk:"app_icon_uri"`
+	Additional types.Map    `tfsdk:"additional"`
 }
 
-func (s *environmentResourceDataSettings) toCreateSDK() *devcyclem.AllOfCreateEnvironmentDtoSettings {
-	return &devcyclem.AllOfCreateEnvironmentDtoSettings{
-		AppIconURI: s.AppIconURI.Value,
+// settingsFromSDK converts the settings returned by the API. When prior lists
+// additional settings, only those are kept, so that settings the API adds are
+// not reported as changes, and prior values equivalent to the returned JSON
+// are kept as written.
+func settingsFromSDK(settings map[string]json.RawMessage, prior *environmentResourceDataSettings) *environmentResourceDataSettings {
+	ret := &environmentResourceDataSettings{
+		AppIconURI: types.String{Value: ""},
+		Additional: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{}},
 	}
+	if raw, ok := settings[appIconURISetting]; ok {
+		_ = json.Unmarshal(raw, &ret.AppIconURI.Value)
+	}
+
+	var priorValues map[string]attr.Value
+	if prior != nil && !prior.Additional.Null && !prior.Additional.Unknown {
+		priorValues = prior.Additional.Elems
+	}
+	for name, raw := range settings {
+		if name == appIconURISetting {
+			continue
+		}
+		priorValue, managed := priorValues[name]
+		if priorValues != nil && !managed {
+			continue
+		}
+		value := string(raw)
+		if priorValue, ok := priorValue.(types.String); ok && jsonEqual(priorValue.Value, value) {
+			value = priorValue.Value
+		}
+		ret.Additional.Elems[name] = types.String{Value: value}
+	}
+	return ret
 }
-func (s *environmentResourceDataSettings) toUpdateSDK() *devcyclem.AllOfUpdateEnvironmentDtoSettings {
-	return &devcyclem.AllOfUpdateEnvironmentDtoSettings{
-		AppIconURI: s.AppIconURI.Value,
+
+const appIconURISetting = "appIconURI"
+
+// toSDK returns the settings that differ from prior, which is nil when the
+// environment was just created. Values that are not configured are left out.
+func (s *environmentResourceDataSettings) toSDK(prior *environmentResourceDataSettings, diags *diag.Diagnostics) map[string]interface{} {
+	settings := map[string]interface{}{}
+	if s == nil {
+		return settings
 	}
+	if prior == nil {
+		prior = &environmentResourceDataSettings{}
+	}
+	if !s.AppIconURI.Null && !s.AppIconURI.Unknown && !s.AppIconURI.Equal(prior.AppIconURI) {
+		settings[appIconURISetting] = s.AppIconURI.Value
+	}
+	if s.Additional.Null || s.Additional.Unknown {
+		return settings
+	}
+	for name, value := range s.Additional.Elems {
+		value, ok := value.(types.String)
+		if !ok || value.Null || value.Unknown {
+			continue
+		}
+		if priorValue, ok := prior.Additional.Elems[name].(types.String); ok && jsonEqual(priorValue.Value, value.Value) {
+			continue
+		}
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value.Value), &decoded); err != nil {
+			diags.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName("settings").WithAttributeName("additional").WithElementKeyString(name),
+				"Invalid Setting Value",
+				fmt.Sprintf("The value of setting %q must be JSON encoded: %s", name, err),
+			)
+			continue
+		}
+		settings[name] = decoded
+	}
+	return settings
+}
+
+// jsonEqual reports whether a and b are equivalent JSON documents.
+func jsonEqual(a, b string) bool {
+	var decodedA, decodedB interface{}
+	if json.Unmarshal([]byte(a), &decodedA) != nil || json.Unmarshal([]byte(b), &decodedB) != nil {
+		return a == b
+	}
+	return reflect.DeepEqual(decodedA, decodedB)
+}
+
+// updateEnvironmentSettings sends the settings that differ from prior and
+// returns the updated environment. The environment is only read when no
+// setting changed.
+func (p provider) updateEnvironmentSettings(ctx context.Context, project, key string, settings, prior *environmentResourceDataSettings, diags *diag.Diagnostics) (environment, *http.Response, error) {
+	body := settings.toSDK(prior, diags)
+	if len(body) == 0 || diags.HasError() {
+		return p.getEnvironment(ctx, project, key)
+	}
+	var ret environment
+	httpResponse, err := p.mgmtRequest(ctx, http.MethodPatch, environmentPath(project, key), map[string]interface{}{"settings": body}, &ret)
+	return ret, httpResponse, err
 }
 
 type environmentResource struct {
@@ -146,25 +285,22 @@ func (r environmentResource) Create(ctx context.Context, req tfsdk.CreateResourc
 		Description: data.Description.Value,
 		Color:       data.Color.Value,
 		Type_:       data.Type.Value,
-		Settings:    data.Settings.toCreateSDK(),
 	}, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
-	data.Settings = environmentResourceDataSettings{
-		AppIconURI: types.String{Value: environment.Settings.AppIconURI},
+	// Settings are sent separately, as the generated client does not model
+	// them, and the response of either request is replaced as it does not
+	// flag compromised or invalidated SDK keys.
+	read, httpResponse, err := r.provider.updateEnvironmentSettings(ctx, data.ProjectId.Value, environment.Key, data.Settings, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	data.ProjectId = types.String{Value: environment.Project}
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+	data.fromSDK(read)
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -191,30 +327,23 @@ func (r environmentResource) Read(ctx context.Context, req tfsdk.ReadResourceReq
 		return
 	}
 
-	environment, httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	environment, httpResponse, err := r.provider.getEnvironment(ctx, data.ProjectId.Value, data.Key.Value)
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "environment no longer exists in DevCycle, removing it from state", "project_id", data.ProjectId.Value, "key", data.Key.Value)
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
-	data.Settings = environmentResourceDataSettings{
-		AppIconURI: types.String{Value: environment.Settings.AppIconURI},
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
 	}
-	data.ProjectId = types.String{Value: environment.Project}
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	data.fromSDK(environment)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 }
 
 func (r environmentResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
-	var data environmentResourceData
+	var data, state environmentResourceData
 	if !r.provider.configured {
 		resp.Diagnostics.AddError(
 			"Provider not configured",
@@ -224,6 +353,8 @@ func (r environmentResource) Update(ctx context.Context, req tfsdk.UpdateResourc
 	}
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
@@ -235,25 +366,21 @@ func (r environmentResource) Update(ctx context.Context, req tfsdk.UpdateResourc
 		Description: data.Description.Value,
 		Color:       data.Color.Value,
 		Type_:       data.Type.Value,
-		Settings:    data.Settings.toUpdateSDK(),
 	}, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: environment.Id}
-	data.Key = types.String{Value: environment.Key}
-	data.Name = types.String{Value: environment.Name}
-	data.Description = types.String{Value: environment.Description}
-	data.Color = types.String{Value: environment.Color}
-	data.Type = types.String{Value: environment.Type_}
-	data.Settings = environmentResourceDataSettings{
-		AppIconURI: types.String{Value: environment.Settings.AppIconURI},
+	// Only the changed settings are sent, so that the others keep the value set
+	// in DevCycle.
+	read, httpResponse, err := r.provider.updateEnvironmentSettings(ctx, data.ProjectId.Value, environment.Key, data.Settings, state.Settings, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	data.ProjectId = types.String{Value: environment.Project}
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Mobile)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Server)...)
-	data.SDKKeys = append(data.SDKKeys, sdkKeyConvert(environment.SdkKeys.Client)...)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+	data.fromSDK(read)
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -280,13 +407,44 @@ func (r environmentResource) Delete(ctx context.Context, req tfsdk.DeleteResourc
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "environment", data.Key.Value, &resp.Diagnostics) {
+		return
+	}
+	if r.provider.PreventProductionDestroy && data.Type.Value == "production" {
+		resp.Diagnostics.AddError(
+			"Production Environment Protected",
+			fmt.Sprintf("The environment %q is a production environment, which cannot be deleted while prevent_production_destroy is enabled in the provider configuration.", data.Key.Value),
+		)
+		return
+	}
+
 	httpResponse, err := r.provider.MgmtClient.EnvironmentsApi.EnvironmentsControllerRemove(ctx, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 	resp.State.RemoveResource(ctx)
 }
 
 func (r environmentResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_key/environment_key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	environment, httpResponse, err := r.provider.getEnvironment(ctx, parts[0], parts[1])
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	// Optional attributes are not configured yet, so they take their defaults.
+	data := environmentResourceData{
+		StoreServerSDKKeys: types.Bool{Null: true},
+		DeletionProtection: types.Bool{Null: true},
+	}
+	data.fromSDK(environment)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -1,8 +1,19 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -18,6 +29,23 @@ func TestAccEnvironmentResource(t *testing.T) {
 					resource.TestCheckResourceAttr("devcycle_environment.test", "project_id", "622112634cabe0e9fbaf974d"),
 				),
 			},
+			{
+				ResourceName:      "devcycle_environment.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectScopedImportStateId("devcycle_environment.test"),
+				ImportStateCheck:  testAccCheckImportedAttributeNull("deletion_protection"),
+			},
+			// Server keys are left out of the state
+			{
+				Config: testAccEnvironmentResourceConfigWriteOnly,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("devcycle_environment.test", "server_sdk_keys.#"),
+					resource.TestCheckResourceAttrSet("devcycle_environment.test", "client_sdk_keys.0.key"),
+					// Settings that are no longer configured keep their value
+					resource.TestCheckResourceAttr("devcycle_environment.test", "settings.app_icon_uri", "test"),
+				),
+			},
 			{
 				Config:  testAccEnvironmentResourceConfig,
 				Destroy: true,
@@ -26,11 +54,13 @@ func TestAccEnvironmentResource(t *testing.T) {
 	})
 }
 
+var testAccEnvironmentKey = randSeq(5)
+
 var testAccEnvironmentResourceConfig = `
 resource "devcycle_environment" "test" {
   project_id = "622112634cabe0e9fbaf974d"
-  name = "TerraformAccTest` + randSeq(5) + `"
-  key = "terraform-acceptance-testing` + randSeq(5) + `"
+  name = "TerraformAccTest` + testAccEnvironmentKey + `"
+  key = "terraform-acceptance-testing` + testAccEnvironmentKey + `"
   description = "Terraform acceptance testing"
   color = "#232323"
   type = "development"
@@ -39,3 +69,140 @@ resource "devcycle_environment" "test" {
   }
 }
 `
+
+var testAccEnvironmentResourceConfigWriteOnly = `
+resource "devcycle_environment" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTest` + testAccEnvironmentKey + `"
+  key = "terraform-acceptance-testing` + testAccEnvironmentKey + `"
+  description = "Terraform acceptance testing"
+  color = "#232323"
+  type = "development"
+  store_server_sdk_keys = false
+}
+`
+
+func TestAccEnvironmentResourceWithoutSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEnvironmentResourceConfigWithoutSettings,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_environment.test", "settings.app_icon_uri", ""),
+				),
+			},
+			// The computed settings do not cause changes
+			{
+				Config:   testAccEnvironmentResourceConfigWithoutSettings,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+var testAccEnvironmentResourceConfigWithoutSettings = `
+resource "devcycle_environment" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTestNoSettings` + testAccEnvironmentKey + `"
+  key = "terraform-acceptance-testing-no-settings` + testAccEnvironmentKey + `"
+  description = "Terraform acceptance testing"
+  color = "#232323"
+  type = "development"
+}
+`
+
+func TestEnvironmentSettings(t *testing.T) {
+	var env environment
+	if err := json.Unmarshal([]byte(`{"key": "development", "settings": {"appIconURI": "https://example.com/icon.png", "limit": 10, "flags": {"a": true}}}`), &env); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := settingsFromSDK(env.Settings, nil)
+	if settings.AppIconURI.Value != "https://example.com/icon.png" || len(settings.Additional.Elems) != 2 {
+		t.Fatalf("unexpected settings %+v", settings)
+	}
+
+	// Once listed, only the configured settings are kept, as written.
+	prior := &environmentResourceDataSettings{
+		AppIconURI: types.String{Null: true},
+		Additional: types.Map{ElemType: types.StringType, Elems: map[string]attr.Value{
+			"flags": types.String{Value: `{ "a": true }`},
+		}},
+	}
+	settings = settingsFromSDK(env.Settings, prior)
+	if len(settings.Additional.Elems) != 1 || !settings.Additional.Elems["flags"].Equal(types.String{Value: `{ "a": true }`}) {
+		t.Fatalf("expected only the prior setting to be kept, got %+v", settings.Additional)
+	}
+
+	// Only changed settings are sent.
+	plan := settingsFromSDK(env.Settings, nil)
+	plan.Additional.Elems["limit"] = types.String{Value: "20"}
+	var diags diag.Diagnostics
+	body := plan.toSDK(settingsFromSDK(env.Settings, nil), &diags)
+	if diags.HasError() || len(body) != 1 || body["limit"] != float64(20) {
+		t.Fatalf("expected only the changed setting, got %v %v", body, diags)
+	}
+
+	plan.Additional.Elems["limit"] = types.String{Value: "twenty"}
+	plan.toSDK(nil, &diags)
+	if !diags.HasError() {
+		t.Error("expected a value that is not JSON to be rejected")
+	}
+}
+
+func TestEnvironmentResourceDataFromSDKWithoutSettings(t *testing.T) {
+	var data environmentResourceData
+	data.fromSDK(environment{Environment: devcyclem.Environment{Key: "development"}})
+	if data.Settings == nil || data.Settings.AppIconURI.Value != "" {
+		t.Errorf("expected empty settings, got %+v", data.Settings)
+	}
+}
+
+func TestEnvironmentResourceImportState(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/project/environments/development" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{
+			"_id": "env-id",
+			"key": "development",
+			"project": "project-id",
+			"sdkKeys": {
+				"server": [{"key": "dvc_server", "createdAt": "2023-01-01T00:00:00Z"}],
+				"client": [{"key": "dvc_client", "createdAt": "2023-01-01T00:00:00Z"}],
+				"mobile": []
+			}
+		}`)
+	}))
+	defer api.Close()
+
+	ctx := context.Background()
+	config := devcyclem.NewConfiguration()
+	config.BasePath = api.URL
+	r := environmentResource{provider: provider{MgmtConfig: config, configured: true}}
+	schema, diags := environmentResourceType{}.GetSchema(ctx)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	resp := &tfsdk.ImportResourceStateResponse{
+		State: tfsdk.State{Schema: schema, Raw: tftypes.NewValue(schema.TerraformType(ctx), nil)},
+	}
+	r.ImportState(ctx, tfsdk.ImportResourceStateRequest{ID: "project/development"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatal(resp.Diagnostics)
+	}
+
+	var data environmentResourceData
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if !data.StoreServerSDKKeys.Null || !data.DeletionProtection.Null {
+		t.Errorf("expected unconfigured attributes to be null, got %v and %v", data.StoreServerSDKKeys, data.DeletionProtection)
+	}
+	if len(data.ServerSDKKeys.Elems) != 1 || len(data.SDKKeys.Elems) != 2 {
+		t.Errorf("expected the server SDK keys to be imported, got %v and %v", data.ServerSDKKeys, data.SDKKeys)
+	}
+}
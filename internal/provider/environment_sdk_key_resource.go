@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// generateSDKKeysDto selects the types of SDK keys to generate for an
+// environment.
+type generateSDKKeysDto struct {
+	Client bool `json:"client,omitempty"`
+	Server bool `json:"server,omitempty"`
+	Mobile bool `json:"mobile,omitempty"`
+}
+
+func environmentSDKKeysPath(project, environment string) string {
+	return fmt.Sprintf("/v1/projects/%s/environments/%s/sdk-keys", url.PathEscape(project), url.PathEscape(environment))
+}
+
+func environmentSDKKeyPath(project, environment, key string) string {
+	return environmentSDKKeysPath(project, environment) + "/" + url.PathEscape(key)
+}
+
+type environmentSDKKeyResourceType struct{}
+
+func (t environmentSDKKeyResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Environment SDK Key resource. Generates an additional SDK key for an environment. To rotate a key, change `keepers` with `create_before_destroy` set and `invalidate_on_destroy` enabled, so that the new key is generated before the old one is invalidated.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project to which the environment belongs",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"environment_id": {
+				MarkdownDescription: "Environment id or key of the environment to generate the key for",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"type": {
+				MarkdownDescription: "SDK key type. One of " + oneOfMarkdown(sdkKeyTypeValues),
+				Required:            true,
+				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{oneOf(sdkKeyTypeValues...)},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"keepers": {
+				MarkdownDescription: "Arbitrary values that generate a new key when changed",
+				Optional:            true,
+				Type:                types.MapType{ElemType: types.StringType},
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"invalidate_on_destroy": {
+				MarkdownDescription: "Whether to invalidate the key when the resource is destroyed, including when it is replaced. Otherwise the key stays valid and is only removed from the Terraform state. Defaults to `false`.",
+				Optional:            true,
+				Type:                types.BoolType,
+			},
+			"key": {
+				MarkdownDescription: "The generated SDK key",
+				Computed:            true,
+				Sensitive:           true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"created_at": {
+				MarkdownDescription: "Date the key was generated, in RFC3339 format",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+			"id": {
+				MarkdownDescription: "SHA-256 hash of the key, identifying it without revealing it",
+				Computed:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+			},
+		},
+	}, nil
+}
+
+func (t environmentSDKKeyResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return environmentSDKKeyResource{
+		provider: provider,
+	}, diags
+}
+
+type environmentSDKKeyResourceData struct {
+	Id                  types.String      `tfsdk:"id"`
+	ProjectId           types.String      `tfsdk:"project_id"`
+	EnvironmentId       types.String      `tfsdk:"environment_id"`
+	Type                types.String      `tfsdk:"type"`
+	Keepers             map[string]string `tfsdk:"keepers"`
+	InvalidateOnDestroy types.Bool        `tfsdk:"invalidate_on_destroy"`
+	Key                 types.String      `tfsdk:"key"`
+	CreatedAt           types.String      `tfsdk:"created_at"`
+}
+
+func (d *environmentSDKKeyResourceData) setKey(key sdkKey) {
+	hash := sha256.Sum256([]byte(key.Key))
+	d.Id = types.String{Value: hex.EncodeToString(hash[:])}
+	d.Key = types.String{Value: key.Key}
+	d.CreatedAt = types.String{Value: key.CreatedAt.UTC().Format(time.RFC3339)}
+}
+
+type environmentSDKKeyResource struct {
+	provider provider
+}
+
+func (r environmentSDKKeyResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data environmentSDKKeyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API returns every key of the environment, so the generated key is
+	// the one that did not exist before.
+	environment, httpResponse, err := r.provider.getEnvironment(ctx, data.ProjectId.Value, data.EnvironmentId.Value)
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+	existing := make(map[string]bool)
+	for _, key := range sdkKeysOfType(environment.SdkKeys, data.Type.Value) {
+		existing[key.Key] = true
+	}
+
+	body := generateSDKKeysDto{
+		Client: data.Type.Value == "client",
+		Server: data.Type.Value == "server",
+		Mobile: data.Type.Value == "mobile",
+	}
+	httpResponse, err = r.provider.mgmtRequest(ctx, http.MethodPost, environmentSDKKeysPath(data.ProjectId.Value, data.EnvironmentId.Value), body, &environment)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+
+	var generated []sdkKey
+	for _, key := range sdkKeysOfType(environment.SdkKeys, data.Type.Value) {
+		if !existing[key.Key] {
+			generated = append(generated, key)
+		}
+	}
+	if len(generated) == 0 {
+		resp.Diagnostics.AddError(
+			"SDK Key Not Generated",
+			fmt.Sprintf("DevCycle did not return a new %s SDK key for environment %q.", data.Type.Value, data.EnvironmentId.Value),
+		)
+		return
+	}
+	sort.Slice(generated, func(i, j int) bool {
+		return generated[i].CreatedAt.After(generated[j].CreatedAt)
+	})
+	data.setKey(generated[0])
+
+	tflog.Trace(ctx, "generated an SDK key", "environment_id", data.EnvironmentId.Value, "type", data.Type.Value, "id", data.Id.Value)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r environmentSDKKeyResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data environmentSDKKeyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, httpResponse, err := r.provider.getEnvironment(ctx, data.ProjectId.Value, data.EnvironmentId.Value)
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "environment no longer exists in DevCycle, removing its SDK key from state", "project_id", data.ProjectId.Value, "environment_id", data.EnvironmentId.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	for _, key := range sdkKeysOfType(environment.SdkKeys, data.Type.Value) {
+		if key.Key == data.Key.Value && !key.Invalidated {
+			data.setKey(key)
+			diags = resp.State.Set(ctx, &data)
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+	}
+	tflog.Warn(ctx, "SDK key was invalidated or removed outside of Terraform, removing it from state", "environment_id", data.EnvironmentId.Value, "id", data.Id.Value)
+	resp.State.RemoveResource(ctx)
+}
+
+func (r environmentSDKKeyResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data environmentSDKKeyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other attribute requires replacement, so only
+	// invalidate_on_destroy, which is not sent to DevCycle, can change here.
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r environmentSDKKeyResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data environmentSDKKeyResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.InvalidateOnDestroy.Value {
+		tflog.Info(ctx, "leaving SDK key valid, as invalidate_on_destroy is not set", "environment_id", data.EnvironmentId.Value, "id", data.Id.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	httpResponse, err := r.provider.mgmtRequest(ctx, http.MethodDelete, environmentSDKKeyPath(data.ProjectId.Value, data.EnvironmentId.Value, data.Key.Value), nil, nil)
+	if !isNotFound(httpResponse) {
+		if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+			return
+		}
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r environmentSDKKeyResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	tfsdk.ResourceImportStateNotImplemented(ctx, "SDK keys cannot be imported, as the key would have to be part of the import identifier. Use the server_sdk_keys, client_sdk_keys and mobile_sdk_keys attributes of the devcycle_environment data source to read existing keys.", resp)
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEnvironmentSDKKeyResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEnvironmentSDKKeyResourceConfig("1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("devcycle_environment_sdk_key.test", "key"),
+					resource.TestCheckResourceAttrSet("devcycle_environment_sdk_key.test", "created_at"),
+				),
+			},
+			// Rotation generates a new key and invalidates the previous one
+			{
+				Config: testAccEnvironmentSDKKeyResourceConfig("2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("devcycle_environment_sdk_key.test", "key"),
+				),
+			},
+			{
+				Config:  testAccEnvironmentSDKKeyResourceConfig("2"),
+				Destroy: true,
+			},
+		},
+	})
+}
+
+func testAccEnvironmentSDKKeyResourceConfig(rotation string) string {
+	return `
+resource "devcycle_environment" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTest` + randString + `"
+  key = "terraform-acceptance-testing` + randString + `"
+  description = "Terraform acceptance testing"
+  color = "#232323"
+  type = "development"
+  settings = {
+	app_icon_uri = "test"
+  }
+}
+
+resource "devcycle_environment_sdk_key" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  environment_id = devcycle_environment.test.key
+  type = "server"
+  invalidate_on_destroy = true
+  keepers = {
+	rotation = "` + rotation + `"
+  }
+
+  lifecycle {
+	create_before_destroy = true
+  }
+}
+`
+}
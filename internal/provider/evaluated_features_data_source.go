@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// evaluatedFeaturesCache holds the features bucketed for each user, so that the
+// data sources evaluating flags for the same user share a single evaluation.
+type evaluatedFeaturesCache struct {
+	mu       sync.Mutex
+	features map[string]map[string]dvc_server.Feature
+}
+
+func newEvaluatedFeaturesCache() *evaluatedFeaturesCache {
+	return &evaluatedFeaturesCache{features: make(map[string]map[string]dvc_server.Feature)}
+}
+
+// allFeatures returns the features bucketed for user, evaluating them with the
+// server SDK the first time they are needed for the user.
+func (p provider) allFeatures(user dvc_server.User, diags *diag.Diagnostics) (map[string]dvc_server.Feature, bool) {
+	client, ok := p.serverClient(diags)
+	if !ok {
+		return nil, false
+	}
+	cache := p.evaluatedFeatures
+	if cache == nil {
+		cache = newEvaluatedFeaturesCache()
+	}
+	key, err := json.Marshal(user)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read Features, got error: %s", err))
+		return nil, false
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if features, ok := cache.features[string(key)]; ok {
+		return features, true
+	}
+	features, err := client.AllFeatures(user)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read Features, got error: %s", err))
+		return nil, false
+	}
+	cache.features[string(key)] = features
+	return features, true
+}
+
+type evaluatedFeaturesDataSourceType struct{}
+
+func (t evaluatedFeaturesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Evaluated Features data source. Returns the variation every feature serves under a single userdata context.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"user": userDataSchema(),
+			"features": {
+				MarkdownDescription: "Features served to the user, by feature key",
+				Computed:            true,
+				Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
+					"id": {
+						MarkdownDescription: "Feature ID",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"type": {
+						MarkdownDescription: "Feature type",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"variation_id": {
+						MarkdownDescription: "ID of the variation served to the user",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"variation_key": {
+						MarkdownDescription: "Key of the variation served to the user",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"variation_name": {
+						MarkdownDescription: "Name of the variation served to the user",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"eval_reason": {
+						MarkdownDescription: "Reason the variation was served, when reported by the bucketing",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+				}, tfsdk.MapNestedAttributesOptions{}),
+			},
+			"id": {
+				Computed: true,
+				Type:     types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t evaluatedFeaturesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return evaluatedFeaturesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type evaluatedFeaturesDataSourceData struct {
+	User     evaluatedVariableDataSourceDataUser               `tfsdk:"user"`
+	Features map[string]evaluatedFeaturesDataSourceDataFeature `tfsdk:"features"`
+	Id       types.String                                      `tfsdk:"id"`
+}
+
+type evaluatedFeaturesDataSourceDataFeature struct {
+	Id            types.String `tfsdk:"id"`
+	Type          types.String `tfsdk:"type"`
+	VariationId   types.String `tfsdk:"variation_id"`
+	VariationKey  types.String `tfsdk:"variation_key"`
+	VariationName types.String `tfsdk:"variation_name"`
+	EvalReason    types.String `tfsdk:"eval_reason"`
+}
+
+type evaluatedFeaturesDataSource struct {
+	provider provider
+}
+
+func (d evaluatedFeaturesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data evaluatedFeaturesDataSourceData
+	if !d.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userData, diags := data.User.toSDK()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	features, ok := d.provider.allFeatures(userData, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	data.Features = make(map[string]evaluatedFeaturesDataSourceDataFeature)
+	for key, feature := range features {
+		data.Features[key] = evaluatedFeaturesDataSourceDataFeature{
+			Id:            types.String{Value: feature.Id},
+			Type:          types.String{Value: feature.Type_},
+			VariationId:   types.String{Value: feature.Variation},
+			VariationKey:  types.String{Value: feature.VariationKey},
+			VariationName: types.String{Value: feature.VariationName},
+			EvalReason:    optionalString(feature.EvalReason),
+		}
+	}
+
+	data.Id = types.String{Value: data.User.Id.Value}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
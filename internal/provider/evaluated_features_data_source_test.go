@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEvaluatedFeaturesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEvaluatedFeaturesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_features.test", "id", "acceptancetesting"),
+					resource.TestCheckResourceAttrSet("data.devcycle_evaluated_features.test", "features.%"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEvaluatedFeaturesDataSourceConfig = `
+data "devcycle_evaluated_features" "test" {
+  user = {
+	id = "acceptancetesting"
+  }
+}
+`
+
+func TestAllFeaturesSharedPerUser(t *testing.T) {
+	var requests int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"feature": {"_id": "feature-id", "key": "feature", "variationKey": "on"}}`)
+	}))
+	defer api.Close()
+
+	client, err := dvc_server.NewDVCClient("server-test", &dvc_server.DVCOptions{
+		EnableCloudBucketing: true,
+		BucketingAPIURI:      api.URL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := provider{ServerClient: client, evaluatedFeatures: newEvaluatedFeaturesCache()}
+
+	var diags diag.Diagnostics
+	for _, userID := range []string{"user-1", "user-1", "user-2"} {
+		features, ok := p.allFeatures(dvc_server.User{UserId: userID}, &diags)
+		if !ok || features["feature"].VariationKey != "on" {
+			t.Fatalf("unexpected features %+v: %v", features, diags)
+		}
+	}
+	if requests := atomic.LoadInt32(&requests); requests != 2 {
+		t.Errorf("expected one evaluation per user, got %d requests", requests)
+	}
+}
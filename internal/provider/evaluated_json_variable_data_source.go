@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -81,10 +80,6 @@ func (d evaluatedJSONVariableDataSource) Read(ctx context.Context, req tfsdk.Rea
 		return
 	}
 
-	userData := dvc_server.DVCUser{
-		UserId: "" + data.User.Id.Value,
-	}
-
 	defaultValueJSON := []byte(data.DefaultValue.Value)
 	var defaultValue map[string]any
 	err := json.Unmarshal(defaultValueJSON, &defaultValue)
@@ -92,9 +87,8 @@ func (d evaluatedJSONVariableDataSource) Read(ctx context.Context, req tfsdk.Rea
 		resp.Diagnostics.AddError("JSON Serialization Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
 		return
 	}
-	variable, err := d.provider.ServerClient.Variable(userData, data.Key.Value, defaultValue)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
+	variable, ok := d.provider.evaluateVariable(data.User, data.Key.Value, defaultValue, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
@@ -2,8 +2,6 @@ package provider
 
 import (
 	"context"
-	"fmt"
-	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -81,13 +79,9 @@ func (d evaluatedNumberVariableDataSource) Read(ctx context.Context, req tfsdk.R
 		return
 	}
 
-	userData := dvc_server.DVCUser{
-		UserId: "" + data.User.Id.Value,
-	}
 	defaultValue, _ := data.DefaultValue.Value.Float64()
-	variable, err := d.provider.ServerClient.Variable(userData, data.Key.Value, defaultValue)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
+	variable, ok := d.provider.evaluateVariable(data.User, data.Key.Value, defaultValue, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
@@ -2,8 +2,6 @@ package provider
 
 import (
 	"context"
-	"fmt"
-	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -80,13 +78,8 @@ func (d evaluatedStringVariableDataSource) Read(ctx context.Context, req tfsdk.R
 		return
 	}
 
-	userData := dvc_server.DVCUser{
-		UserId: "" + data.User.Id.Value,
-	}
-
-	variable, err := d.provider.ServerClient.Variable(userData, data.Key.Value, data.DefaultValue.Value)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
+	variable, ok := d.provider.evaluateVariable(data.User, data.Key.Value, data.DefaultValue.Value, &resp.Diagnostics)
+	if !ok {
 		return
 	}
 
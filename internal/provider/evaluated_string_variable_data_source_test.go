@@ -18,6 +18,13 @@ func TestAccEvaluatedStringFeatureDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.devcycle_evaluated_variable_string.test", "value", "String"),
 				),
 			},
+			// Full user context
+			{
+				Config: testAccEvaluatedStringVariableDataSourceConfigFullUser,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variable_string.test", "value", "String"),
+				),
+			},
 		},
 	})
 }
@@ -31,3 +38,27 @@ data "devcycle_evaluated_variable_string" "test" {
   default_value = false
 }
 `
+
+const testAccEvaluatedStringVariableDataSourceConfigFullUser = `
+data "devcycle_evaluated_variable_string" "test" {
+  key = "acceptance-testing-string"
+  user = {
+	id = "acceptancetesting"
+	email = "acceptancetesting@devcycle.com"
+	name = "Acceptance Testing"
+	country = "CA"
+	language = "en"
+	device_model = "terraform"
+	app_version = "1.0.0"
+	app_build = "1"
+	custom_data = jsonencode({
+	  beta = true
+	  seats = 10
+	})
+	private_custom_data = jsonencode({
+	  plan = "enterprise"
+	})
+  }
+  default_value = false
+}
+`
@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// evaluateVariable evaluates the variable key for user with the server SDK. It
+// is the code path shared by all evaluated variable data sources.
+func (p provider) evaluateVariable(user evaluatedVariableDataSourceDataUser, key string, defaultValue interface{}, diags *diag.Diagnostics) (dvc_server.Variable, bool) {
+	userData, userDiags := user.toSDK()
+	diags.Append(userDiags...)
+	if diags.HasError() {
+		return dvc_server.Variable{}, false
+	}
+
+	client, ok := p.serverClient(diags)
+	if !ok {
+		return dvc_server.Variable{}, false
+	}
+	variable, err := client.Variable(userData, key, defaultValue)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
+		return variable, false
+	}
+	return variable, true
+}
+
+// serverClient returns the server SDK client evaluating feature flags, adding a
+// diagnostic when the provider was configured without a server SDK token.
+func (p provider) serverClient(diags *diag.Diagnostics) (*dvc_server.DVCClient, bool) {
+	if p.ServerClient == nil {
+		diags.AddError(
+			"Missing Server SDK Token",
+			"Evaluating feature flags requires a server SDK token. Set server_sdk_token or server_sdk_environment in the provider configuration, or the DEVCYCLE_SERVER_TOKEN environment variable.",
+		)
+		return nil, false
+	}
+	return p.ServerClient, true
+}
+
+// variableDefaultValue parses a default value given as a string into the Go
+// type the server SDK expects for variableType. An empty value yields the zero
+// value of the type.
+func variableDefaultValue(variableType string, value string) (interface{}, error) {
+	switch variableType {
+	case "Boolean":
+		if value == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(value)
+	case "Number":
+		if value == "" {
+			return float64(0), nil
+		}
+		return strconv.ParseFloat(value, 64)
+	case "JSON":
+		var ret map[string]interface{}
+		if value == "" {
+			return map[string]interface{}{}, nil
+		}
+		err := json.Unmarshal([]byte(value), &ret)
+		return ret, err
+	case "String":
+		return value, nil
+	}
+	return nil, fmt.Errorf("unsupported variable type %q", variableType)
+}
+
+type evaluatedVariableDataSourceType struct{}
+
+func (t evaluatedVariableDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Evaluated Variable data source. Evaluates a variable of any type under a single userdata context, looking up the variable type with the management API.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"user": userDataSchema(),
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project the variable belongs to",
+				Required:            true,
+				Type:                types.StringType,
+			},
+			"key": {
+				Required:            true,
+				MarkdownDescription: "Variable ID or key. Recommended to use the key when not managing an entire project in Terraform.",
+				Type:                types.StringType,
+			},
+			"default_value": {
+				MarkdownDescription: "Default value of the Variable, as a string parsed according to the variable type. JSON defaults must be a JSON object. Defaults to the zero value of the type.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"type": {
+				MarkdownDescription: "Variable type. One of `String`, `Boolean`, `Number` or `JSON`",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"value": {
+				MarkdownDescription: "Value of the Variable as a string. JSON values are JSON encoded.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"value_string": {
+				MarkdownDescription: "Value of a String Variable",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"value_bool": {
+				MarkdownDescription: "Value of a Boolean Variable",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"value_number": {
+				MarkdownDescription: "Value of a Number Variable",
+				Computed:            true,
+				Type:                types.Float64Type,
+			},
+			"value_json": {
+				MarkdownDescription: "JSON encoded value of a JSON Variable. Use `jsondecode` to access its fields.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"is_defaulted": {
+				MarkdownDescription: "Whether the default value was returned, because the user is not served a variation containing the variable",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"feature_key": {
+				MarkdownDescription: "Key of the feature serving the variable to the user. Null when the value is defaulted.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"variation_key": {
+				MarkdownDescription: "Key of the variation served to the user. Null when the value is defaulted.",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"id": {
+				Computed: true,
+				Type:     types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t evaluatedVariableDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return evaluatedVariableDataSource{
+		provider: provider,
+	}, diags
+}
+
+type evaluatedVariableDataSourceData struct {
+	User         evaluatedVariableDataSourceDataUser `tfsdk:"user"`
+	ProjectId    types.String                        `tfsdk:"project_id"`
+	Key          types.String                        `tfsdk:"key"`
+	DefaultValue types.String                        `tfsdk:"default_value"`
+	Type         types.String                        `tfsdk:"type"`
+	Value        types.String                        `tfsdk:"value"`
+	ValueString  types.String                        `tfsdk:"value_string"`
+	ValueBool    types.Bool                          `tfsdk:"value_bool"`
+	ValueNumber  types.Float64                       `tfsdk:"value_number"`
+	ValueJSON    types.String                        `tfsdk:"value_json"`
+	IsDefaulted  types.Bool                          `tfsdk:"is_defaulted"`
+	FeatureKey   types.String                        `tfsdk:"feature_key"`
+	VariationKey types.String                        `tfsdk:"variation_key"`
+	Id           types.String                        `tfsdk:"id"`
+}
+
+// setValue sets the typed value attributes, leaving those of other types null.
+func (d *evaluatedVariableDataSourceData) setValue(value interface{}) error {
+	d.ValueString = types.String{Null: true}
+	d.ValueBool = types.Bool{Null: true}
+	d.ValueNumber = types.Float64{Null: true}
+	d.ValueJSON = types.String{Null: true}
+
+	switch v := value.(type) {
+	case string:
+		d.Value = types.String{Value: v}
+		d.ValueString = types.String{Value: v}
+	case bool:
+		d.Value = types.String{Value: strconv.FormatBool(v)}
+		d.ValueBool = types.Bool{Value: v}
+	case float64:
+		d.Value = types.String{Value: strconv.FormatFloat(v, 'f', -1, 64)}
+		d.ValueNumber = types.Float64{Value: v}
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		d.Value = types.String{Value: string(encoded)}
+		d.ValueJSON = types.String{Value: string(encoded)}
+	}
+	return nil
+}
+
+type evaluatedVariableDataSource struct {
+	provider provider
+}
+
+func (d evaluatedVariableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data evaluatedVariableDataSourceData
+	if !d.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	mgmtVariable, httpResponse, err := d.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
+	if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	defaultValue, err := variableDefaultValue(mgmtVariable.Type_, data.DefaultValue.Value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("default_value"),
+			"Invalid Default Value",
+			fmt.Sprintf("Default value is not a valid %s value: %s", mgmtVariable.Type_, err),
+		)
+		return
+	}
+
+	variable, ok := d.provider.evaluateVariable(data.User, mgmtVariable.Key, defaultValue, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	data.Key = types.String{Value: mgmtVariable.Key}
+	data.Id = data.Key
+	data.Type = types.String{Value: mgmtVariable.Type_}
+	data.IsDefaulted = types.Bool{Value: variable.IsDefaulted}
+	if err := data.setValue(variable.Value); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variable, got error: %s", err))
+		return
+	}
+
+	data.FeatureKey = types.String{Null: true}
+	data.VariationKey = types.String{Null: true}
+	if !variable.IsDefaulted {
+		// Shared with the other data sources evaluating flags for the user, so
+		// that reads do not each add a round trip to the bucketing API.
+		userData, _ := data.User.toSDK()
+		features, ok := d.provider.allFeatures(userData, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		for _, feature := range features {
+			if feature.Id == mgmtVariable.Feature {
+				data.FeatureKey = types.String{Value: feature.Key}
+				data.VariationKey = types.String{Value: feature.VariationKey}
+				break
+			}
+		}
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEvaluatedVariableDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEvaluatedVariableDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variable.string", "type", "String"),
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variable.string", "value", "String"),
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variable.string", "value_string", "String"),
+					resource.TestCheckNoResourceAttr("data.devcycle_evaluated_variable.string", "value_bool"),
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variable.boolean", "type", "Boolean"),
+					resource.TestCheckResourceAttrSet("data.devcycle_evaluated_variable.boolean", "value_bool"),
+					resource.TestCheckResourceAttrSet("data.devcycle_evaluated_variable.boolean", "is_defaulted"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEvaluatedVariableDataSourceConfig = `
+data "devcycle_evaluated_variable" "string" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key = "acceptance-testing-string"
+  user = {
+	id = "acceptancetesting"
+  }
+}
+
+data "devcycle_evaluated_variable" "boolean" {
+  project_id = "622112634cabe0e9fbaf974d"
+  key = "acceptance-testing-boolean"
+  user = {
+	id = "acceptancetesting"
+  }
+  default_value = "false"
+}
+`
+
+func TestEvaluateVariableWithoutServerSDKToken(t *testing.T) {
+	var diags diag.Diagnostics
+	_, ok := provider{}.evaluateVariable(evaluatedVariableDataSourceDataUser{Id: types.String{Value: "user"}}, "key", "default", &diags)
+	if ok || !diags.HasError() || diags[0].Summary() != "Missing Server SDK Token" {
+		t.Fatalf("expected a missing server SDK token error, got %v", diags)
+	}
+}
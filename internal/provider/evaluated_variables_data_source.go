@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+type evaluatedVariablesDataSourceType struct{}
+
+func (t evaluatedVariablesDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Evaluated Variables data source. Evaluates every variable under a single userdata context in a single bucketing call.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"user": userDataSchema(),
+			"keys": {
+				MarkdownDescription: "Only return these variable keys. Keys that are not served to the user are returned with `is_defaulted` set and a null value.",
+				Optional:            true,
+				Type:                types.ListType{ElemType: types.StringType},
+			},
+			"key_prefix": {
+				MarkdownDescription: "Only return variables whose key starts with this prefix",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"variables": {
+				MarkdownDescription: "Evaluated variables, by variable key",
+				Computed:            true,
+				Attributes: tfsdk.MapNestedAttributes(map[string]tfsdk.Attribute{
+					"value": {
+						MarkdownDescription: "JSON encoded value of the Variable. Use `jsondecode` to access it.",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"type": {
+						MarkdownDescription: "Variable type",
+						Computed:            true,
+						Type:                types.StringType,
+					},
+					"is_defaulted": {
+						MarkdownDescription: "Whether the variable is not served to the user",
+						Computed:            true,
+						Type:                types.BoolType,
+					},
+				}, tfsdk.MapNestedAttributesOptions{}),
+			},
+			"id": {
+				Computed: true,
+				Type:     types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t evaluatedVariablesDataSourceType) NewDataSource(ctx context.Context, in tfsdk.Provider) (tfsdk.DataSource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return evaluatedVariablesDataSource{
+		provider: provider,
+	}, diags
+}
+
+type evaluatedVariablesDataSourceData struct {
+	User      evaluatedVariableDataSourceDataUser                 `tfsdk:"user"`
+	Keys      []string                                            `tfsdk:"keys"`
+	KeyPrefix types.String                                        `tfsdk:"key_prefix"`
+	Variables map[string]evaluatedVariablesDataSourceDataVariable `tfsdk:"variables"`
+	Id        types.String                                        `tfsdk:"id"`
+}
+
+type evaluatedVariablesDataSourceDataVariable struct {
+	Value       types.String `tfsdk:"value"`
+	Type        types.String `tfsdk:"type"`
+	IsDefaulted types.Bool   `tfsdk:"is_defaulted"`
+}
+
+type evaluatedVariablesDataSource struct {
+	provider provider
+}
+
+func (d evaluatedVariablesDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRequest, resp *tfsdk.ReadDataSourceResponse) {
+	var data evaluatedVariablesDataSourceData
+	if !d.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	userData, diags := data.User.toSDK()
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, ok := d.provider.serverClient(&resp.Diagnostics)
+	if !ok {
+		return
+	}
+	variables, err := client.AllVariables(userData)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read Variables, got error: %s", err))
+		return
+	}
+
+	data.Variables = make(map[string]evaluatedVariablesDataSourceDataVariable)
+	for key, variable := range variables {
+		if !strings.HasPrefix(key, data.KeyPrefix.Value) {
+			continue
+		}
+		value, err := json.Marshal(variable.Value)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to encode Variable %s, got error: %s", key, err))
+			return
+		}
+		data.Variables[key] = evaluatedVariablesDataSourceDataVariable{
+			Value:       types.String{Value: string(value)},
+			Type:        types.String{Value: variable.Type_},
+			IsDefaulted: types.Bool{Value: false},
+		}
+	}
+
+	if data.Keys != nil {
+		filtered := make(map[string]evaluatedVariablesDataSourceDataVariable)
+		for _, key := range data.Keys {
+			if !strings.HasPrefix(key, data.KeyPrefix.Value) {
+				continue
+			}
+			variable, ok := data.Variables[key]
+			if !ok {
+				variable = evaluatedVariablesDataSourceDataVariable{
+					Value:       types.String{Null: true},
+					Type:        types.String{Null: true},
+					IsDefaulted: types.Bool{Value: true},
+				}
+			}
+			filtered[key] = variable
+		}
+		data.Variables = filtered
+	}
+
+	data.Id = types.String{Value: data.User.Id.Value}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccEvaluatedVariablesDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: testAccEvaluatedVariablesDataSourceConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variables.test", "variables.%", "2"),
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variables.test", "variables.acceptance-testing-string.value", `"String"`),
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variables.test", "variables.acceptance-testing-string.type", "String"),
+					resource.TestCheckResourceAttr("data.devcycle_evaluated_variables.test", "variables.acceptance-testing-boolean-no-exist.is_defaulted", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccEvaluatedVariablesDataSourceConfig = `
+data "devcycle_evaluated_variables" "test" {
+  user = {
+	id = "acceptancetesting"
+  }
+  keys = ["acceptance-testing-string", "acceptance-testing-boolean-no-exist"]
+}
+`
@@ -170,7 +170,7 @@ func (d featureDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 	}
 
 	feature, httpResponse, err := d.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.Key.Value, data.ProjectKey.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -182,7 +182,7 @@ func (d featureDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 	data.ProjectKey = types.String{Value: feature.Project}
 	data.Type = types.String{Value: feature.Type_}
 	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	data.Variations = variationToTF(feature.Variations, featureVariableTypes(data.Variables), nil)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -10,8 +10,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 )
 
 type featureResourceType struct{}
@@ -36,6 +38,7 @@ func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 				MarkdownDescription: "Feature key",
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{keyValidator{}},
 				PlanModifiers: tfsdk.AttributePlanModifiers{
 					tfsdk.RequiresReplace(),
 				},
@@ -46,10 +49,12 @@ func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 				Type:                types.StringType,
 			},
 			"type": {
-				MarkdownDescription: "Feature Type",
+				MarkdownDescription: "Feature Type. One of " + oneOfMarkdown(featureTypeValues),
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{oneOf(featureTypeValues...)},
 			},
+			"deletion_protection": deletionProtectionAttribute("feature"),
 			"source": {
 				MarkdownDescription: "Source of Feature creation",
 				Computed:            true,
@@ -68,6 +73,7 @@ func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 						Type:                types.StringType,
 						Required:            true,
 						MarkdownDescription: "Variation key",
+						Validators:          []tfsdk.AttributeValidator{keyValidator{}},
 					},
 					"name": {
 						Type:                types.StringType,
@@ -77,7 +83,7 @@ func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 					"variables": {
 						Type:                types.MapType{ElemType: types.StringType},
 						Required:            true,
-						MarkdownDescription: "Variation variables",
+						MarkdownDescription: "Variation variables, keyed by variable key. Values are validated against the variable types when the feature declares its `variables`.",
 					},
 					"id": {
 						Type:                types.StringType,
@@ -107,6 +113,7 @@ func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 						Type:                types.StringType,
 						Required:            true,
 						MarkdownDescription: "Variation key",
+						Validators:          []tfsdk.AttributeValidator{keyValidator{}},
 					},
 					"feature_key": {
 						Type:                types.StringType,
@@ -117,6 +124,7 @@ func (t featureResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 						Type:                types.StringType,
 						Required:            true,
 						MarkdownDescription: "Variation type",
+						Validators:          []tfsdk.AttributeValidator{oneOf(variableTypeValues...)},
 					},
 					"id": {
 						Type:                types.StringType,
@@ -166,15 +174,31 @@ type featureResourceData struct {
 	Tags        []string                       `tfsdk:"tags"`
 	Variations  []featureResourceDataVariation `tfsdk:"variations"`
 	Variables   []featureResourceDataVariable  `tfsdk:"variables"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+}
+
+func (d *featureResourceData) fromSDK(feature devcyclem.Feature, variableTypes map[string]string) {
+	d.Id = types.String{Value: feature.Id}
+	d.Key = types.String{Value: feature.Key}
+	d.Name = types.String{Value: feature.Name}
+	d.Description = types.String{Value: feature.Description}
+	d.Type = types.String{Value: feature.Type_}
+	d.Tags = feature.Tags
+	d.ProjectId = types.String{Value: feature.Project}
+	d.Source = types.String{Value: feature.Source}
+	d.Variables = variableToTF(feature.Variables)
+	d.Variations = variationToTF(feature.Variations, variableTypes, d.Variations)
 }
 
-func (t featureResourceData) variationToSDK() []devcyclem.FeatureVariationDto {
+func (t featureResourceData) variationToSDK(variableTypes map[string]string, diags *diag.Diagnostics) []devcyclem.FeatureVariationDto {
 	var variations []devcyclem.FeatureVariationDto
-	for _, variation := range t.Variations {
+	for i, variation := range t.Variations {
+		path := tftypes.NewAttributePath().WithAttributeName("variations").WithElementKeyInt(i).WithAttributeName("variables")
 		variations = append(variations, devcyclem.FeatureVariationDto{
 			Key:       variation.Key.Value,
 			Name:      variation.Name.Value,
-			Variables: variation.variationMapTypeFix(t.Variables),
+			Variables: variation.variationVariablesToSDK(variableTypes, path, diags),
 		})
 	}
 	return variations
@@ -213,85 +237,141 @@ type featureResourceDataVariation struct {
 	Variables map[string]string `tfsdk:"variables"`
 }
 
-func variationMapToString(variations map[string]interface{}, variables []featureResourceDataVariable) map[string]string {
-	ret := make(map[string]string)
-	for k, v := range variations {
-		for _, variable := range variables {
-			if variable.Key.Value == k {
-				switch variable.Type.Value {
-				case "String":
-					ret[k] = v.(string)
-					break
-				case "Number":
-					ret[k] = fmt.Sprintf("%f", v.(float64))
-					break
-				case "Boolean":
-					ret[k] = fmt.Sprintf("%t", v.(bool))
-					break
-				case "JSON":
-					marshal, err := json.Marshal(v)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing json", err)
-						return nil
-					}
-					ret[k] = string(marshal)
-					break
-				}
-			}
+// variationValueToSDK parses a variation value from its string form in
+// Terraform to the type of its variable.
+func variationValueToSDK(variableType, value string) (interface{}, error) {
+	switch variableType {
+	case "Number":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid number", value)
+		}
+		return f, nil
+	case "Boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a valid boolean", value)
+		}
+		return b, nil
+	case "JSON":
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("%q is not valid JSON: %s", value, err)
+		}
+		return v, nil
+	}
+	return value, nil
+}
+
+// variationValueToTF formats a variation value as a string in its normalized
+// form: numbers without trailing zeros and compact JSON with sorted keys. The
+// prior value is kept when it represents the same value, so that formatting
+// choices in the configuration do not cause diffs.
+func variationValueToTF(variableType string, value interface{}, prior *string) string {
+	var ret string
+	switch v := value.(type) {
+	case string:
+		ret = v
+	case float64:
+		ret = strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		ret = strconv.FormatBool(v)
+	default:
+		marshalled, err := json.Marshal(v)
+		if err != nil {
+			ret = fmt.Sprintf("%v", v)
+		} else {
+			ret = string(marshalled)
+		}
+	}
+
+	if prior != nil && *prior != ret {
+		priorValue, err := variationValueToSDK(variableType, *prior)
+		if err == nil && reflect.DeepEqual(priorValue, normalizeVariationValue(value)) {
+			return *prior
 		}
-		ret[k] = fmt.Sprintf("%v", v)
 	}
 	return ret
 }
 
-func (f *featureResourceDataVariation) variationMapTypeFix(variables []featureResourceDataVariable) map[string]interface{} {
-	ret := make(map[string]interface{})
+// normalizeVariationValue converts a value decoded by the management client to
+// the representation returned by variationValueToSDK, for comparisons.
+func normalizeVariationValue(value interface{}) interface{} {
+	marshalled, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var ret interface{}
+	if err := json.Unmarshal(marshalled, &ret); err != nil {
+		return value
+	}
+	return ret
+}
 
+// variationVariablesToSDK converts the variable values of a variation to their
+// variable types, adding an error at path for every invalid value.
+func (f featureResourceDataVariation) variationVariablesToSDK(variableTypes map[string]string, path *tftypes.AttributePath, diags *diag.Diagnostics) map[string]interface{} {
+	ret := make(map[string]interface{}, len(f.Variables))
 	for k, v := range f.Variables {
-		for _, variable := range variables {
-			if variable.Key.Value == k {
-				switch variable.Type.Value {
-				case "String":
-					ret[k] = v
-					break
-				case "JSON":
-					var marshalled interface{}
-					err := json.Unmarshal([]byte(v), &marshalled)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing json", err)
-						return nil
-					}
-					break
-				case "Number":
-					float, err := strconv.ParseFloat(v, 64)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing float", err)
-						return nil
-					}
-					ret[k] = float
-					break
-				case "Boolean":
-					b, err := strconv.ParseBool(v)
-					if err != nil {
-						tflog.Error(context.Background(), "Error parsing bool", err)
-						return nil
-					}
-					ret[k] = b
-					break
-				}
-			}
+		variableType, ok := variableTypes[k]
+		if !ok {
+			diags.AddAttributeError(
+				path.WithElementKeyString(k),
+				"Unknown Variable",
+				fmt.Sprintf("Variable %q is not a variable of this feature. Add it to the feature variables, or attach it to the feature with a devcycle_variable resource.", k),
+			)
+			continue
+		}
+		value, err := variationValueToSDK(variableType, v)
+		if err != nil {
+			diags.AddAttributeError(
+				path.WithElementKeyString(k),
+				"Invalid Variation Value",
+				fmt.Sprintf("The value of %s variable %q is invalid: %s", variableType, k, err),
+			)
+			continue
+		}
+		ret[k] = value
+	}
+	return ret
+}
+
+func variationVariablesToTF(values map[string]interface{}, variableTypes map[string]string, prior map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	ret := make(map[string]string, len(values))
+	for k, v := range values {
+		var priorValue *string
+		if p, ok := prior[k]; ok {
+			priorValue = &p
 		}
+		ret[k] = variationValueToTF(variableTypes[k], v, priorValue)
 	}
 	return ret
 }
 
-func variationToTF(variations []devcyclem.Variation, variables []featureResourceDataVariable) []featureResourceDataVariation {
+// featureVariableTypes returns the types of the given variables by key.
+func featureVariableTypes(variables []featureResourceDataVariable) map[string]string {
+	variableTypes := make(map[string]string, len(variables))
+	for _, variable := range variables {
+		variableTypes[variable.Key.Value] = variable.Type.Value
+	}
+	return variableTypes
+}
+
+func variationToTF(variations []devcyclem.Variation, variableTypes map[string]string, prior []featureResourceDataVariation) []featureResourceDataVariation {
+	priorValues := make(map[string]map[string]string, len(prior))
+	for _, variation := range prior {
+		priorValues[variation.Key.Value] = variation.Variables
+	}
+
 	var ret []featureResourceDataVariation
 	for _, variation := range variations {
 		nvar := featureResourceDataVariation{
 			Key:       types.String{Value: variation.Key},
 			Name:      types.String{Value: variation.Name},
-			Variables: variationMapToString(variation.Variables, variables),
+			Variables: variationVariablesToTF(variation.Variables, variableTypes, priorValues[variation.Key]),
 			Id:        types.String{Value: variation.Id},
 		}
 		ret = append(ret, nvar)
@@ -328,6 +408,178 @@ type featureResource struct {
 	provider provider
 }
 
+// variableTypes returns the types of the feature variables and of the
+// variables used by the feature variations, given by key. Variables which are
+// not part of the feature variables, such as those managed with
+// devcycle_variable resources, are looked up in the project.
+func (r featureResource) variableTypes(ctx context.Context, projectID string, variables []featureResourceDataVariable, keys []string, diags *diag.Diagnostics) map[string]string {
+	variableTypes := featureVariableTypes(variables)
+	// Variations share variables, so each key is looked up once, including
+	// those which do not exist.
+	notFound := make(map[string]bool)
+	for _, key := range keys {
+		if _, ok := variableTypes[key]; ok || notFound[key] {
+			continue
+		}
+		variable, httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, key, projectID)
+		if isNotFound(httpResponse) {
+			// Reported as an unknown variable by variationToSDK.
+			notFound[key] = true
+			continue
+		}
+		if handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, diags) {
+			return variableTypes
+		}
+		variableTypes[key] = variable.Type_
+	}
+	return variableTypes
+}
+
+// variationVariableKeys returns the keys of the variables set by the
+// configured variations.
+func (t featureResourceData) variationVariableKeys() []string {
+	var keys []string
+	for _, variation := range t.Variations {
+		for key := range variation.Variables {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// sdkVariationVariableKeys returns the keys of the variables set by the
+// variations of a feature read from DevCycle.
+func sdkVariationVariableKeys(variations []devcyclem.Variation) []string {
+	var keys []string
+	for _, variation := range variations {
+		for key := range variation.Variables {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func (r featureResource) ConfigValidators(ctx context.Context) []tfsdk.ResourceConfigValidator {
+	return []tfsdk.ResourceConfigValidator{featureVariationsValidator{}}
+}
+
+// featureVariationsValidator checks the variation values of a feature against
+// the types of its variables during planning, rather than leaving them to be
+// rejected by the API on apply.
+//
+// Only features declaring their variables are checked, as the types of
+// variables managed with devcycle_variable resources are not known until they
+// are looked up on apply.
+type featureVariationsValidator struct{}
+
+func (v featureVariationsValidator) Description(ctx context.Context) string {
+	return "every variation must set a value of the right type for every feature variable, and only for feature variables"
+}
+
+func (v featureVariationsValidator) MarkdownDescription(ctx context.Context) string {
+	return "every variation must set a value of the right type for every feature variable in `variables`, and only for those"
+}
+
+// featureVariableConfig and featureVariationConfig hold the parts of the
+// configuration used for validation, which may contain unknown values.
+type featureVariableConfig struct {
+	Id          types.String `tfsdk:"id"`
+	Key         types.String `tfsdk:"key"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	FeatureKey  types.String `tfsdk:"feature_key"`
+	Type        types.String `tfsdk:"type"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+	UpdatedAt   types.String `tfsdk:"updated_at"`
+}
+
+type featureVariationConfig struct {
+	Id        types.String `tfsdk:"id"`
+	Key       types.String `tfsdk:"key"`
+	Name      types.String `tfsdk:"name"`
+	Variables types.Map    `tfsdk:"variables"`
+}
+
+func (v featureVariationsValidator) Validate(ctx context.Context, req tfsdk.ValidateResourceConfigRequest, resp *tfsdk.ValidateResourceConfigResponse) {
+	var variables, variations types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("variables"), &variables)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("variations"), &variations)...)
+	if resp.Diagnostics.HasError() || variables.Null || variables.Unknown || variations.Null || variations.Unknown {
+		return
+	}
+
+	var declared []featureVariableConfig
+	resp.Diagnostics.Append(variables.ElementsAs(ctx, &declared, false)...)
+	var configured []featureVariationConfig
+	resp.Diagnostics.Append(variations.ElementsAs(ctx, &configured, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variableTypes := make(map[string]string, len(declared))
+	for _, variable := range declared {
+		if variable.Key.Unknown || variable.Type.Unknown {
+			return
+		}
+		variableTypes[variable.Key.Value] = variable.Type.Value
+	}
+	variableKeys := make([]string, 0, len(variableTypes))
+	for key := range variableTypes {
+		variableKeys = append(variableKeys, key)
+	}
+	sort.Strings(variableKeys)
+
+	for i, variation := range configured {
+		path := tftypes.NewAttributePath().WithAttributeName("variations").WithElementKeyInt(i).WithAttributeName("variables")
+		if variation.Variables.Null || variation.Variables.Unknown {
+			continue
+		}
+		var values map[string]types.String
+		resp.Diagnostics.Append(variation.Variables.ElementsAs(ctx, &values, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		keys := make([]string, 0, len(values))
+		for key := range values {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			variableType, ok := variableTypes[key]
+			if !ok {
+				resp.Diagnostics.AddAttributeError(
+					path.WithElementKeyString(key),
+					"Unknown Variable",
+					fmt.Sprintf("Variable %q is not one of the feature variables: %s.", key, strings.Join(variableKeys, ", ")),
+				)
+				continue
+			}
+			value := values[key]
+			if value.Null || value.Unknown {
+				continue
+			}
+			if _, err := variationValueToSDK(variableType, value.Value); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.WithElementKeyString(key),
+					"Invalid Variation Value",
+					fmt.Sprintf("The value of %s variable %q is invalid: %s", variableType, key, err),
+				)
+			}
+		}
+
+		for _, key := range variableKeys {
+			if _, ok := values[key]; !ok {
+				resp.Diagnostics.AddAttributeError(
+					path,
+					"Missing Variation Value",
+					fmt.Sprintf("Variation %q does not set a value for variable %q. Every variation must set a value for every feature variable.", variation.Key.Value, key),
+				)
+			}
+		}
+	}
+}
+
 func (r featureResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
 	var data featureResourceData
 
@@ -345,16 +597,22 @@ func (r featureResource) Create(ctx context.Context, req tfsdk.CreateResourceReq
 		return
 	}
 
+	variableTypes := r.variableTypes(ctx, data.ProjectId.Value, data.Variables, data.variationVariableKeys(), &resp.Diagnostics)
+	variations := data.variationToSDK(variableTypes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerCreate(ctx, devcyclem.CreateFeatureDto{
 		Name:        data.Name.Value,
 		Key:         data.Key.Value,
 		Description: data.Description.Value,
-		Variations:  data.variationToSDK(),
+		Variations:  variations,
 		Variables:   data.variablesToSDK(),
 		Type_:       data.Type.Value,
 		Tags:        data.Tags,
 	}, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -367,7 +625,10 @@ func (r featureResource) Create(ctx context.Context, req tfsdk.CreateResourceReq
 	data.ProjectId = types.String{Value: feature.Project}
 	data.Source = types.String{Value: feature.Source}
 	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	for key, variableType := range featureVariableTypes(data.Variables) {
+		variableTypes[key] = variableType
+	}
+	data.Variations = variationToTF(feature.Variations, variableTypes, data.Variations)
 
 	// write logs using the tflog package
 	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
@@ -395,20 +656,20 @@ func (r featureResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 	}
 
 	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "feature no longer exists in DevCycle, removing it from state", "project_id", data.ProjectId.Value, "key", data.Key.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Id = types.String{Value: feature.Id}
-	data.Key = types.String{Value: feature.Key}
-	data.Name = types.String{Value: feature.Name}
-	data.Description = types.String{Value: feature.Description}
-	data.Type = types.String{Value: feature.Type_}
-	data.Tags = feature.Tags
-	data.ProjectId = types.String{Value: feature.Project}
-	data.Source = types.String{Value: feature.Source}
-	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	variableTypes := r.variableTypes(ctx, feature.Project, variableToTF(feature.Variables), sdkVariationVariableKeys(feature.Variations), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.fromSDK(feature, variableTypes)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -430,6 +691,12 @@ func (r featureResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 		return
 	}
 
+	variableTypes := r.variableTypes(ctx, data.ProjectId.Value, data.Variables, data.variationVariableKeys(), &resp.Diagnostics)
+	variations := data.variationToSDK(variableTypes, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerUpdate(ctx, devcyclem.UpdateFeatureDto{
 		Name:        data.Name.Value,
 		Key:         data.Key.Value,
@@ -437,9 +704,9 @@ func (r featureResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 		Type_:       data.Type.Value,
 		Tags:        data.Tags,
 		Variables:   data.variablesToSDK(),
-		Variations:  data.variationToSDK(),
+		Variations:  variations,
 	}, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -452,7 +719,10 @@ func (r featureResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 	data.ProjectId = types.String{Value: feature.Project}
 	data.Source = types.String{Value: feature.Source}
 	data.Variables = variableToTF(feature.Variables)
-	data.Variations = variationToTF(feature.Variations, data.Variables)
+	for key, variableType := range featureVariableTypes(data.Variables) {
+		variableTypes[key] = variableType
+	}
+	data.Variations = variationToTF(feature.Variations, variableTypes, data.Variations)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -474,14 +744,18 @@ func (r featureResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "feature", data.Key.Value, &resp.Diagnostics) {
+		return
+	}
+
 	for _, variable := range data.Variables {
 		httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerRemove(ctx, variable.Id.Value, data.ProjectId.Value)
-		if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+		if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 			return
 		}
 	}
 	httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerRemove(ctx, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -489,5 +763,26 @@ func (r featureResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 }
 
 func (r featureResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_key/feature_key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, parts[1], parts[0])
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	variableTypes := r.variableTypes(ctx, feature.Project, variableToTF(feature.Variables), sdkVariationVariableKeys(feature.Variations), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data := featureResourceData{DeletionProtection: types.Bool{Null: true}}
+	data.fromSDK(feature, variableTypes)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
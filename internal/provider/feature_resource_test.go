@@ -1,8 +1,18 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
 	"testing"
 
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -25,6 +35,22 @@ func TestAccFeatureResource(t *testing.T) {
 					resource.TestCheckResourceAttr("devcycle_feature.test", "description", "Terraform acceptance testing edited"),
 				),
 			},
+			{
+				ResourceName:      "devcycle_feature.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectScopedImportStateId("devcycle_feature.test"),
+				ImportStateCheck:  testAccCheckImportedAttributeNull("deletion_protection"),
+			},
+			// Typed variation values round trip without diffs
+			{
+				Config: testAccFeatureResourceConfigTyped,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature.test", "variations.0.variables."+testAccFeatureVariableKey+"2", "1.50"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "variations.0.variables."+testAccFeatureVariableKey+"3", "true"),
+					resource.TestCheckResourceAttr("devcycle_feature.test", "variations.0.variables."+testAccFeatureVariableKey+"4", `{ "enabled": true, "limit": 10 }`),
+				),
+			},
 			{
 				Config:  testAccFeatureResourceConfig,
 				Destroy: true,
@@ -103,3 +129,215 @@ output "testing" {
   value = devcycle_feature.test.variables
 }
 `
+
+var testAccFeatureVariableKey = "test-variable-key" + randString
+
+var testAccFeatureResourceConfigTyped = `
+resource "devcycle_feature" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTest` + randString + `"
+  key = "terraform-acceptance-testing` + randString + `"
+  description = "Terraform acceptance testing edited"
+  type = "experiment"
+  tags = ["acceptance-testing"]
+  variables = [
+	{
+	  name = "test-variable-name` + randString + `"
+	  description = "description"
+      key = "test-variable-key` + randString + `"
+      type = "String"
+	},
+	{
+	  name = "test-variable-name` + randString + `2"
+	  description = "description"
+      key = "test-variable-key` + randString + `2"
+      type = "Number"
+	},
+	{
+	  name = "test-variable-name` + randString + `3"
+	  description = "description"
+      key = "test-variable-key` + randString + `3"
+      type = "Boolean"
+	},
+	{
+	  name = "test-variable-name` + randString + `4"
+	  description = "description"
+      key = "test-variable-key` + randString + `4"
+      type = "JSON"
+	}
+  ]
+  variations = [
+	{
+		key = "test-variation-key` + randString + `"
+		name = "test-variation-name` + randString + `"
+		variables = {
+			"test-variable-key` + randString + `" = "2"
+			"test-variable-key` + randString + `2" = "1.50"
+			"test-variable-key` + randString + `3" = "true"
+			"test-variable-key` + randString + `4" = "{ \"enabled\": true, \"limit\": 10 }"
+		}
+	}
+  ]
+}
+`
+
+func TestVariationValueRoundTrip(t *testing.T) {
+	cases := []struct {
+		variableType string
+		config       string
+		api          interface{}
+		normalized   string
+	}{
+		{"String", "hello", "hello", "hello"},
+		{"Number", "1.50", 1.5, "1.5"},
+		{"Number", "10", float64(10), "10"},
+		{"Boolean", "true", true, "true"},
+		{"JSON", `{ "b": [1, 2], "a": "x" }`, map[string]interface{}{"a": "x", "b": []interface{}{float64(1), float64(2)}}, `{"a":"x","b":[1,2]}`},
+	}
+	for _, c := range cases {
+		value, err := variationValueToSDK(c.variableType, c.config)
+		if err != nil {
+			t.Fatalf("%s %q: %s", c.variableType, c.config, err)
+		}
+		if !reflect.DeepEqual(value, c.api) {
+			t.Errorf("%s %q: expected %#v, got %#v", c.variableType, c.config, c.api, value)
+		}
+		if got := variationValueToTF(c.variableType, c.api, nil); got != c.normalized {
+			t.Errorf("%s: expected normalized %q, got %q", c.variableType, c.normalized, got)
+		}
+		if got := variationValueToTF(c.variableType, c.api, &c.config); got != c.config {
+			t.Errorf("%s: expected the equivalent prior value %q to be kept, got %q", c.variableType, c.config, got)
+		}
+	}
+
+	changed := "2"
+	if got := variationValueToTF("Number", 1.5, &changed); got != "1.5" {
+		t.Errorf("expected a changed prior value to be replaced, got %q", got)
+	}
+	if _, err := variationValueToSDK("Boolean", "yes please"); err == nil {
+		t.Error("expected an invalid boolean to be rejected")
+	}
+}
+
+func TestVariationToTFVariableTypes(t *testing.T) {
+	// The variable is not one of the feature variables, e.g. it is managed
+	// with a devcycle_variable resource, so its type is looked up separately.
+	config := `{ "enabled": true }`
+	variations := []devcyclem.Variation{{
+		Key:       "on",
+		Variables: map[string]interface{}{"external": map[string]interface{}{"enabled": true}},
+	}}
+	prior := []featureResourceDataVariation{{
+		Key:       types.String{Value: "on"},
+		Variables: map[string]string{"external": config},
+	}}
+	ret := variationToTF(variations, map[string]string{"external": "JSON"}, prior)
+	if got := ret[0].Variables["external"]; got != config {
+		t.Errorf("expected the equivalent configured JSON %q to be kept, got %q", config, got)
+	}
+}
+
+func TestFeatureResourceVariableTypesLooksUpEachKeyOnce(t *testing.T) {
+	requests := make(map[string]int)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if r.URL.Path != "/v1/projects/project/variables/external" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"_id": "variable-id", "key": "external", "type": "JSON"}`)
+	}))
+	defer api.Close()
+
+	config := devcyclem.NewConfiguration()
+	config.BasePath = api.URL
+	r := featureResource{provider: provider{MgmtClient: devcyclem.NewAPIClient(config), configured: true}}
+
+	var diags diag.Diagnostics
+	keys := []string{"missing", "external", "missing", "external", "own"}
+	variables := []featureResourceDataVariable{{Key: types.String{Value: "own"}, Type: types.String{Value: "String"}}}
+	variableTypes := r.variableTypes(context.Background(), "project", variables, keys, &diags)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if expected := map[string]string{"own": "String", "external": "JSON"}; !reflect.DeepEqual(variableTypes, expected) {
+		t.Errorf("expected %v, got %v", expected, variableTypes)
+	}
+	if expected := map[string]int{"/v1/projects/project/variables/missing": 1, "/v1/projects/project/variables/external": 1}; !reflect.DeepEqual(requests, expected) {
+		t.Errorf("expected each unknown key to be looked up once, got %v", requests)
+	}
+}
+
+func TestFeatureVariationsValidator(t *testing.T) {
+	ctx := context.Background()
+	schema, _ := featureResourceType{}.GetSchema(ctx)
+	objectType := schema.TerraformType(ctx).(tftypes.Object)
+	variableType := objectType.AttributeTypes["variables"].(tftypes.List).ElementType.(tftypes.Object)
+	variationType := objectType.AttributeTypes["variations"].(tftypes.List).ElementType.(tftypes.Object)
+
+	variable := func(key, typ string) tftypes.Value {
+		attributes := make(map[string]tftypes.Value, len(variableType.AttributeTypes))
+		for name, attributeType := range variableType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		attributes["key"] = tftypes.NewValue(tftypes.String, key)
+		attributes["type"] = tftypes.NewValue(tftypes.String, typ)
+		return tftypes.NewValue(variableType, attributes)
+	}
+	variation := func(values map[string]string) tftypes.Value {
+		variables := make(map[string]tftypes.Value, len(values))
+		for k, v := range values {
+			variables[k] = tftypes.NewValue(tftypes.String, v)
+		}
+		return tftypes.NewValue(variationType, map[string]tftypes.Value{
+			"id":        tftypes.NewValue(tftypes.String, nil),
+			"key":       tftypes.NewValue(tftypes.String, "variation"),
+			"name":      tftypes.NewValue(tftypes.String, "Variation"),
+			"variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, variables),
+		})
+	}
+	validate := func(values map[string]string) diag.Diagnostics {
+		attributes := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			attributes[name] = tftypes.NewValue(attributeType, nil)
+		}
+		attributes["variables"] = tftypes.NewValue(objectType.AttributeTypes["variables"], []tftypes.Value{
+			variable("flag", "Boolean"),
+			variable("limit", "Number"),
+		})
+		attributes["variations"] = tftypes.NewValue(objectType.AttributeTypes["variations"], []tftypes.Value{variation(values)})
+
+		resp := &tfsdk.ValidateResourceConfigResponse{}
+		featureVariationsValidator{}.Validate(ctx, tfsdk.ValidateResourceConfigRequest{
+			Config: tfsdk.Config{Schema: schema, Raw: tftypes.NewValue(objectType, attributes)},
+		}, resp)
+		return resp.Diagnostics
+	}
+
+	if diags := validate(map[string]string{"flag": "true", "limit": "10"}); diags.HasError() {
+		t.Errorf("expected valid variations, got %v", diags)
+	}
+
+	path := tftypes.NewAttributePath().WithAttributeName("variations").WithElementKeyInt(0).WithAttributeName("variables")
+	cases := []struct {
+		values  map[string]string
+		path    *tftypes.AttributePath
+		summary string
+	}{
+		{map[string]string{"flag": "true", "limit": "abc"}, path.WithElementKeyString("limit"), "Invalid Variation Value"},
+		{map[string]string{"flag": "true", "limit": "1", "other": "x"}, path.WithElementKeyString("other"), "Unknown Variable"},
+		{map[string]string{"flag": "true"}, path, "Missing Variation Value"},
+	}
+	for _, c := range cases {
+		diags := validate(c.values)
+		if len(diags) != 1 {
+			t.Errorf("%v: expected a single error, got %v", c.values, diags)
+			continue
+		}
+		withPath, ok := diags[0].(diag.DiagnosticWithPath)
+		if !ok || diags[0].Summary() != c.summary || !withPath.Path().Equal(c.path) {
+			t.Errorf("%v: expected %q at %s, got %v", c.values, c.summary, c.path, diags[0])
+		}
+	}
+}
@@ -0,0 +1,554 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/antihax/optional"
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type featureTargetingResourceType struct{}
+
+func (t featureTargetingResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostics) {
+	return tfsdk.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "DevCycle Feature Targeting resource. Manages the status and the ordered targeting rules of a feature in a single environment.",
+
+		Attributes: map[string]tfsdk.Attribute{
+			"project_id": {
+				MarkdownDescription: "Project id or key of the project to which the feature belongs",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"feature_id": {
+				MarkdownDescription: "Feature id or key of the feature to target",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"environment_id": {
+				MarkdownDescription: "Environment id or key of the environment the targeting applies to",
+				Required:            true,
+				Type:                types.StringType,
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.RequiresReplace(),
+				},
+			},
+			"enabled": {
+				MarkdownDescription: "Whether the feature is enabled in the environment",
+				Required:            true,
+				Type:                types.BoolType,
+			},
+			"targets": {
+				MarkdownDescription: "Ordered targeting rules. A user is served by the first target whose audience they match, so the order of this list is significant.",
+				Optional:            true,
+				Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+					"name": {
+						MarkdownDescription: "Target name",
+						Optional:            true,
+						Type:                types.StringType,
+					},
+					"audience": {
+						MarkdownDescription: "Audience describing which users this target applies to",
+						Required:            true,
+						Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+							"name": {
+								MarkdownDescription: "Audience name",
+								Optional:            true,
+								Type:                types.StringType,
+							},
+							"operator": {
+								MarkdownDescription: "Operator used to combine the audience filters. Either `and` or `or`",
+								Required:            true,
+								Type:                types.StringType,
+							},
+							"filters": audienceFiltersSchema(),
+						}),
+					},
+					"serve": {
+						MarkdownDescription: "Variation key to serve to every user in the target. Conflicts with `distribution`.",
+						Optional:            true,
+						Type:                types.StringType,
+					},
+					"distribution": {
+						MarkdownDescription: "Percentage split of variations served to users in the target. Conflicts with `serve`.",
+						Optional:            true,
+						Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+							"variation": {
+								MarkdownDescription: "Variation key",
+								Required:            true,
+								Type:                types.StringType,
+							},
+							"percentage": {
+								MarkdownDescription: "Fraction of users served this variation, between 0 and 1",
+								Required:            true,
+								Type:                types.Float64Type,
+							},
+						}, tfsdk.ListNestedAttributesOptions{}),
+					},
+					"rollout": {
+						MarkdownDescription: "Rollout of the target audience over time",
+						Optional:            true,
+						Attributes: tfsdk.SingleNestedAttributes(map[string]tfsdk.Attribute{
+							"type": {
+								MarkdownDescription: "Rollout type. One of `schedule`, `gradual` or `stepped`",
+								Required:            true,
+								Type:                types.StringType,
+							},
+							"start_percentage": {
+								MarkdownDescription: "Fraction of the audience the rollout starts at, between 0 and 1",
+								Optional:            true,
+								Type:                types.Float64Type,
+							},
+							"start_date": {
+								MarkdownDescription: "Date the rollout starts, in RFC3339 format",
+								Required:            true,
+								Type:                types.StringType,
+							},
+							"stages": {
+								MarkdownDescription: "Rollout stages",
+								Optional:            true,
+								Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+									"type": {
+										MarkdownDescription: "Transition into this stage. Either `linear` or `discrete`",
+										Required:            true,
+										Type:                types.StringType,
+									},
+									"percentage": {
+										MarkdownDescription: "Fraction of the audience to reach by the stage date, between 0 and 1",
+										Required:            true,
+										Type:                types.Float64Type,
+									},
+									"date": {
+										MarkdownDescription: "Date the stage percentage is fully applied, in RFC3339 format",
+										Required:            true,
+										Type:                types.StringType,
+									},
+								}, tfsdk.ListNestedAttributesOptions{}),
+							},
+						}),
+					},
+				}, tfsdk.ListNestedAttributesOptions{}),
+			},
+			"id": {
+				Computed:            true,
+				MarkdownDescription: "Feature targeting ID, in the form `project/feature/environment`",
+				PlanModifiers: tfsdk.AttributePlanModifiers{
+					tfsdk.UseStateForUnknown(),
+				},
+				Type: types.StringType,
+			},
+		},
+	}, nil
+}
+
+func (t featureTargetingResourceType) NewResource(ctx context.Context, in tfsdk.Provider) (tfsdk.Resource, diag.Diagnostics) {
+	provider, diags := convertProviderType(in)
+
+	return featureTargetingResource{
+		provider: provider,
+	}, diags
+}
+
+type featureTargetingResourceData struct {
+	Id            types.String                         `tfsdk:"id"`
+	ProjectId     types.String                         `tfsdk:"project_id"`
+	FeatureId     types.String                         `tfsdk:"feature_id"`
+	EnvironmentId types.String                         `tfsdk:"environment_id"`
+	Enabled       types.Bool                           `tfsdk:"enabled"`
+	Targets       []featureTargetingResourceDataTarget `tfsdk:"targets"`
+}
+
+type featureTargetingResourceDataTarget struct {
+	Name         types.String                               `tfsdk:"name"`
+	Audience     featureTargetingResourceDataAudience       `tfsdk:"audience"`
+	Serve        types.String                               `tfsdk:"serve"`
+	Distribution []featureTargetingResourceDataDistribution `tfsdk:"distribution"`
+	Rollout      *featureTargetingResourceDataRollout       `tfsdk:"rollout"`
+}
+
+type featureTargetingResourceDataAudience struct {
+	Name     types.String              `tfsdk:"name"`
+	Operator types.String              `tfsdk:"operator"`
+	Filters  []audienceFilterGroupData `tfsdk:"filters"`
+}
+
+type featureTargetingResourceDataDistribution struct {
+	Variation  types.String  `tfsdk:"variation"`
+	Percentage types.Float64 `tfsdk:"percentage"`
+}
+
+type featureTargetingResourceDataRollout struct {
+	Type            types.String                               `tfsdk:"type"`
+	StartPercentage types.Float64                              `tfsdk:"start_percentage"`
+	StartDate       types.String                               `tfsdk:"start_date"`
+	Stages          []featureTargetingResourceDataRolloutStage `tfsdk:"stages"`
+}
+
+type featureTargetingResourceDataRolloutStage struct {
+	Type       types.String  `tfsdk:"type"`
+	Percentage types.Float64 `tfsdk:"percentage"`
+	Date       types.String  `tfsdk:"date"`
+}
+
+func featureTargetingStatus(enabled bool) string {
+	if enabled {
+		return "active"
+	}
+	return "inactive"
+}
+
+func parseRolloutDate(value string, path *tftypes.AttributePath, diags *diag.Diagnostics) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		diags.AddAttributeError(path, "Invalid Rollout Date", fmt.Sprintf("%q is not a valid RFC3339 date: %s", value, err))
+	}
+	return t
+}
+
+// rolloutDateToTF keeps the configured representation of a date when it refers
+// to the same instant the API returned, so formatting differences do not diff.
+func rolloutDateToTF(prior types.String, t time.Time) types.String {
+	if !prior.Null && !prior.Unknown {
+		if p, err := time.Parse(time.RFC3339, prior.Value); err == nil && p.Equal(t) {
+			return prior
+		}
+	}
+	return types.String{Value: t.UTC().Format(time.RFC3339)}
+}
+
+func (t featureTargetingResourceData) targetsToSDK() ([]devcyclem.UpdateTargetDto, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	targets := []devcyclem.UpdateTargetDto{}
+
+	for i, target := range t.Targets {
+		targetPath := tftypes.NewAttributePath().WithAttributeName("targets").WithElementKeyInt(i)
+
+		filters, filterDiags := audienceFiltersToSDK(target.Audience.Operator.Value, target.Audience.Filters)
+		diags.Append(filterDiags...)
+
+		var distribution []devcyclem.TargetDistribution
+		switch {
+		case !target.Serve.Null && target.Distribution != nil:
+			diags.AddAttributeError(targetPath.WithAttributeName("serve"), "Conflicting Target Configuration", "Only one of `serve` or `distribution` can be set on a target.")
+		case !target.Serve.Null:
+			distribution = []devcyclem.TargetDistribution{{Variation: target.Serve.Value, Percentage: 1}}
+		case target.Distribution != nil:
+			for _, d := range target.Distribution {
+				distribution = append(distribution, devcyclem.TargetDistribution{
+					Variation:  d.Variation.Value,
+					Percentage: d.Percentage.Value,
+				})
+			}
+		default:
+			diags.AddAttributeError(targetPath, "Missing Target Configuration", "One of `serve` or `distribution` must be set on a target.")
+		}
+
+		var rollout *devcyclem.AllOfUpdateTargetDtoRollout
+		if target.Rollout != nil {
+			rolloutPath := targetPath.WithAttributeName("rollout")
+			rollout = &devcyclem.AllOfUpdateTargetDtoRollout{
+				Type_:           target.Rollout.Type.Value,
+				StartPercentage: target.Rollout.StartPercentage.Value,
+				StartDate:       parseRolloutDate(target.Rollout.StartDate.Value, rolloutPath.WithAttributeName("start_date"), &diags),
+			}
+			for j, stage := range target.Rollout.Stages {
+				rollout.Stages = append(rollout.Stages, devcyclem.RolloutStage{
+					Type_:      stage.Type.Value,
+					Percentage: stage.Percentage.Value,
+					Date:       parseRolloutDate(stage.Date.Value, rolloutPath.WithAttributeName("stages").WithElementKeyInt(j).WithAttributeName("date"), &diags),
+				})
+			}
+		}
+
+		targets = append(targets, devcyclem.UpdateTargetDto{
+			Name: target.Name.Value,
+			Audience: &devcyclem.AllOfUpdateTargetDtoAudience{
+				Name:    target.Audience.Name.Value,
+				Filters: filters,
+			},
+			Distribution: distribution,
+			Rollout:      rollout,
+		})
+	}
+	return targets, diags
+}
+
+// targetsToTF converts the API targets to Terraform, translating variation IDs
+// back into the variation keys used in configuration. prior is the previous
+// value of the targets, used to keep the configured shape of each target.
+func targetsToTF(targets []devcyclem.Target, variations []devcyclem.Variation, prior []featureTargetingResourceDataTarget) ([]featureTargetingResourceDataTarget, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var ret []featureTargetingResourceDataTarget
+	if prior != nil && len(prior) == 0 {
+		// The API returns no targets for a configured empty list.
+		ret = []featureTargetingResourceDataTarget{}
+	}
+
+	variationKeys := make(map[string]string)
+	for _, variation := range variations {
+		variationKeys[variation.Id] = variation.Key
+		variationKeys[variation.Key] = variation.Key
+	}
+	variationKey := func(v string) string {
+		if key, ok := variationKeys[v]; ok {
+			return key
+		}
+		return v
+	}
+
+	for i, target := range targets {
+		var priorTarget *featureTargetingResourceDataTarget
+		if i < len(prior) {
+			priorTarget = &prior[i]
+		}
+
+		ntarget := featureTargetingResourceDataTarget{
+			Name:  optionalString(target.Name),
+			Serve: types.String{Null: true},
+		}
+
+		if target.Audience != nil {
+			var priorFilters []audienceFilterGroupData
+			if priorTarget != nil {
+				priorFilters = priorTarget.Audience.Filters
+			}
+			operator, filters, filterDiags := audienceFiltersToTF(target.Audience.Filters, priorFilters)
+			diags.Append(filterDiags...)
+			ntarget.Audience = featureTargetingResourceDataAudience{
+				Name:     optionalString(target.Audience.Name),
+				Operator: operator,
+				Filters:  filters,
+			}
+		}
+
+		servesSingleVariation := len(target.Distribution) == 1 && target.Distribution[0].Percentage == 1
+		if servesSingleVariation && (priorTarget == nil || priorTarget.Distribution == nil) {
+			ntarget.Serve = types.String{Value: variationKey(target.Distribution[0].Variation)}
+		} else {
+			for _, d := range target.Distribution {
+				ntarget.Distribution = append(ntarget.Distribution, featureTargetingResourceDataDistribution{
+					Variation:  types.String{Value: variationKey(d.Variation)},
+					Percentage: types.Float64{Value: d.Percentage},
+				})
+			}
+		}
+
+		if target.Rollout != nil {
+			var priorRollout featureTargetingResourceDataRollout
+			if priorTarget != nil && priorTarget.Rollout != nil {
+				priorRollout = *priorTarget.Rollout
+			}
+			rolloutType, _ := target.Rollout.Type_.(string)
+			ntarget.Rollout = &featureTargetingResourceDataRollout{
+				Type:            types.String{Value: rolloutType},
+				StartPercentage: types.Float64{Null: true},
+				StartDate:       rolloutDateToTF(priorRollout.StartDate, target.Rollout.StartDate),
+			}
+			if target.Rollout.StartPercentage != 0 || (!priorRollout.StartPercentage.Null && !priorRollout.StartPercentage.Unknown) {
+				ntarget.Rollout.StartPercentage = types.Float64{Value: target.Rollout.StartPercentage}
+			}
+			for j, stage := range target.Rollout.Stages {
+				var priorDate types.String
+				if j < len(priorRollout.Stages) {
+					priorDate = priorRollout.Stages[j].Date
+				}
+				stageType, _ := stage.Type_.(string)
+				ntarget.Rollout.Stages = append(ntarget.Rollout.Stages, featureTargetingResourceDataRolloutStage{
+					Type:       types.String{Value: stageType},
+					Percentage: types.Float64{Value: stage.Percentage},
+					Date:       rolloutDateToTF(priorDate, stage.Date),
+				})
+			}
+		}
+
+		ret = append(ret, ntarget)
+	}
+	return ret, diags
+}
+
+type featureTargetingResource struct {
+	provider provider
+}
+
+func (r featureTargetingResource) update(ctx context.Context, data *featureTargetingResourceData, schema tfsdk.Schema, diags *diag.Diagnostics) {
+	targets, targetDiags := data.targetsToSDK()
+	diags.Append(targetDiags...)
+	if diags.HasError() {
+		return
+	}
+
+	config, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeatureConfigsControllerUpdate(ctx, devcyclem.UpdateFeatureConfigDto{
+		Targets: targets,
+		Status:  featureTargetingStatus(data.Enabled.Value),
+	}, data.EnvironmentId.Value, data.FeatureId.Value, data.ProjectId.Value)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, schema, diags); ret {
+		return
+	}
+
+	r.setFromConfig(ctx, data, config, diags)
+}
+
+func (r featureTargetingResource) setFromConfig(ctx context.Context, data *featureTargetingResourceData, config devcyclem.FeatureConfig, diags *diag.Diagnostics) {
+	feature, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeaturesControllerFindOne(ctx, data.FeatureId.Value, data.ProjectId.Value)
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, diags); ret {
+		return
+	}
+
+	targets, targetDiags := targetsToTF(config.Targets, feature.Variations, data.Targets)
+	diags.Append(targetDiags...)
+
+	data.Id = types.String{Value: strings.Join([]string{data.ProjectId.Value, data.FeatureId.Value, data.EnvironmentId.Value}, "/")}
+	data.Enabled = types.Bool{Value: config.Status == "active"}
+	data.Targets = targets
+}
+
+func (r featureTargetingResource) Create(ctx context.Context, req tfsdk.CreateResourceRequest, resp *tfsdk.CreateResourceResponse) {
+	var data featureTargetingResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Config.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.update(ctx, &data, req.Plan.Schema, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created feature targeting", "id", data.Id.Value)
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r featureTargetingResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest, resp *tfsdk.ReadResourceResponse) {
+	var data featureTargetingResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	configs, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeatureConfigsControllerFindAll(ctx, data.FeatureId.Value, data.ProjectId.Value, &devcyclem.FeaturesApiFeatureConfigsControllerFindAllOpts{
+		Environment: optional.NewInterface(data.EnvironmentId.Value),
+	})
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "feature targeting no longer exists in DevCycle, removing it from state", "project_id", data.ProjectId.Value, "feature_id", data.FeatureId.Value, "environment_id", data.EnvironmentId.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+	if len(configs) == 0 {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("No configuration found for feature %s in environment %s", data.FeatureId.Value, data.EnvironmentId.Value))
+		return
+	}
+
+	r.setFromConfig(ctx, &data, configs[0], &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r featureTargetingResource) Update(ctx context.Context, req tfsdk.UpdateResourceRequest, resp *tfsdk.UpdateResourceResponse) {
+	var data featureTargetingResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.Plan.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.update(ctx, &data, req.Plan.Schema, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+}
+
+func (r featureTargetingResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRequest, resp *tfsdk.DeleteResourceResponse) {
+	var data featureTargetingResourceData
+	if !r.provider.configured {
+		resp.Diagnostics.AddError(
+			"Provider not configured",
+			"The provider hasn't been configured before apply, likely because it depends on an unknown value from another resource. Authentication is required to be configured.",
+		)
+		return
+	}
+	diags := req.State.Get(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Feature configurations cannot be deleted, so disable the feature and clear its targeting instead.
+	_, httpResponse, err := r.provider.MgmtClient.FeaturesApi.FeatureConfigsControllerUpdate(ctx, devcyclem.UpdateFeatureConfigDto{
+		Targets: []devcyclem.UpdateTargetDto{},
+		Status:  featureTargetingStatus(false),
+	}, data.EnvironmentId.Value, data.FeatureId.Value, data.ProjectId.Value)
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	resp.State.RemoveResource(ctx)
+}
+
+func (r featureTargetingResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project/feature/environment. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("feature_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, tftypes.NewAttributePath().WithAttributeName("environment_id"), parts[2])...)
+}
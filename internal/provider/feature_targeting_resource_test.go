@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFeatureTargetingResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccFeatureTargetingResourceConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.#", "2"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.name", "Internal users"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.serve", "test-variation-key"+randString),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.1.distribution.#", "2"),
+				),
+			},
+			// Reordering targets
+			{
+				Config: testAccFeatureTargetingResourceConfigReordered,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.0.name", "Everyone"),
+					resource.TestCheckResourceAttr("devcycle_feature_targeting.test", "targets.1.name", "Internal users"),
+				),
+			},
+			{
+				ResourceName:      "devcycle_feature_targeting.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:  testAccFeatureTargetingResourceConfigReordered,
+				Destroy: true,
+			},
+		},
+	})
+}
+
+var testAccFeatureTargetingFeatureConfig = `
+resource "devcycle_feature" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  name = "TerraformAccTest` + randString + `"
+  key = "terraform-acceptance-testing` + randString + `"
+  description = "Terraform acceptance testing"
+  type = "release"
+  tags = ["acceptance-testing"]
+  variables = [
+	{
+	  name = "test-variable-name` + randString + `"
+	  description = "description"
+      key = "test-variable-key` + randString + `"
+      type = "Boolean"
+	}
+  ]
+  variations = [
+	{
+		key = "test-variation-key` + randString + `"
+		name = "test-variation-name` + randString + `"
+		variables = {
+			"test-variable-key` + randString + `" = "true"
+		}
+	},
+	{
+		key = "test-variation-key` + randString + `-off"
+		name = "test-variation-name` + randString + `-off"
+		variables = {
+			"test-variable-key` + randString + `" = "false"
+		}
+	}
+  ]
+}
+`
+
+var testAccFeatureTargetingResourceConfig = testAccFeatureTargetingFeatureConfig + `
+resource "devcycle_feature_targeting" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  feature_id = devcycle_feature.test.key
+  environment_id = "development"
+  enabled = true
+  targets = [
+	{
+	  name = "Internal users"
+	  audience = {
+		operator = "and"
+		filters = [
+		  {
+			type = "user"
+			sub_type = "email"
+			comparator = "contain"
+			values = ["@devcycle.com"]
+		  }
+		]
+	  }
+	  serve = "test-variation-key` + randString + `"
+	},
+	{
+	  name = "Everyone"
+	  audience = {
+		operator = "and"
+		filters = [
+		  {
+			type = "all"
+		  }
+		]
+	  }
+	  distribution = [
+		{
+		  variation = "test-variation-key` + randString + `"
+		  percentage = 0.5
+		},
+		{
+		  variation = "test-variation-key` + randString + `-off"
+		  percentage = 0.5
+		}
+	  ]
+	}
+  ]
+}
+`
+
+var testAccFeatureTargetingResourceConfigReordered = testAccFeatureTargetingFeatureConfig + `
+resource "devcycle_feature_targeting" "test" {
+  project_id = "622112634cabe0e9fbaf974d"
+  feature_id = devcycle_feature.test.key
+  environment_id = "development"
+  enabled = false
+  targets = [
+	{
+	  name = "Everyone"
+	  audience = {
+		operator = "and"
+		filters = [
+		  {
+			type = "all"
+		  }
+		]
+	  }
+	  distribution = [
+		{
+		  variation = "test-variation-key` + randString + `"
+		  percentage = 0.5
+		},
+		{
+		  variation = "test-variation-key` + randString + `-off"
+		  percentage = 0.5
+		}
+	  ]
+	},
+	{
+	  name = "Internal users"
+	  audience = {
+		operator = "and"
+		filters = [
+		  {
+			type = "user"
+			sub_type = "email"
+			comparator = "contain"
+			values = ["@devcycle.com"]
+		  }
+		]
+	  }
+	  serve = "test-variation-key` + randString + `"
+	}
+  ]
+}
+`
+
+func TestTargetsToTFKeepsEmptyTargets(t *testing.T) {
+	targets, diags := targetsToTF(nil, nil, []featureTargetingResourceDataTarget{})
+	if diags.HasError() || targets == nil || len(targets) != 0 {
+		t.Errorf("expected configured empty targets to be kept, got %#v", targets)
+	}
+	if targets, _ := targetsToTF(nil, nil, nil); targets != nil {
+		t.Errorf("expected null targets without a prior value, got %#v", targets)
+	}
+}
@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// mgmtAPIError is returned by mgmtRequest for non-2xx responses. Like the
+// errors of the generated management client, it exposes the raw response body.
+type mgmtAPIError struct {
+	status string
+	body   []byte
+}
+
+func (e mgmtAPIError) Error() string {
+	return e.status
+}
+
+func (e mgmtAPIError) Body() []byte {
+	return e.body
+}
+
+// mgmtRequest calls a management API endpoint that is not covered by the
+// generated client, using the same base path, headers and HTTP client.
+// body and out are JSON encoded and decoded when non-nil.
+func (p provider) mgmtRequest(ctx context.Context, method, path string, body interface{}, out interface{}) (*http.Response, error) {
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(p.MgmtConfig.BasePath, "/")+path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	for header, value := range p.MgmtConfig.DefaultHeader {
+		req.Header.Set(header, value)
+	}
+	req.Header.Set("User-Agent", p.MgmtConfig.UserAgent)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := p.MgmtConfig.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResponse, err := client.Do(req)
+	if err != nil {
+		return httpResponse, err
+	}
+	defer httpResponse.Body.Close()
+
+	respBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return httpResponse, err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return httpResponse, mgmtAPIError{status: httpResponse.Status, body: respBody}
+	}
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return httpResponse, fmt.Errorf("unable to decode response from %s %s: %w", method, path, err)
+		}
+	}
+	return httpResponse, nil
+}
@@ -43,6 +43,7 @@ func (t projectDataSourceType) GetSchema(ctx context.Context) (tfsdk.Schema, dia
 				Computed:            true,
 				Type:                types.StringType,
 			},
+			"settings": projectSettingsAttribute(true),
 		},
 	}, nil
 }
@@ -61,6 +62,8 @@ type projectDataSourceData struct {
 	Description  types.String `tfsdk:"description"`
 	Id           types.String `tfsdk:"id"`
 	Organization types.String `tfsdk:"organization"`
+
+	Settings *projectSettingsData `tfsdk:"settings"`
 }
 
 type projectDataSource struct {
@@ -83,8 +86,8 @@ func (d projectDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 		return
 	}
 
-	project, httpResponse, err := d.provider.MgmtClient.ProjectsApi.ProjectsControllerFindOne(ctx, data.Key.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	project, httpResponse, err := d.provider.getProject(ctx, data.Key.Value)
+	if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 	data.Name = types.String{Value: project.Name}
@@ -92,6 +95,7 @@ func (d projectDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceReq
 	data.Organization = types.String{Value: project.Organization}
 	data.Id = types.String{Value: project.Id}
 	data.Description = types.String{Value: project.Description}
+	data.Settings = projectSettingsFromSDK(project)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
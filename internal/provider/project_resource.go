@@ -2,13 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
@@ -34,6 +34,7 @@ func (t projectResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 				MarkdownDescription: "Project key, usually the lowercase, kebab case name of the project",
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{keyValidator{}},
 			},
 			"id": {
 				Computed:            true,
@@ -43,6 +44,8 @@ func (t projectResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag.
 				},
 				Type: types.StringType,
 			},
+			"settings":            projectSettingsAttribute(false),
+			"deletion_protection": deletionProtectionAttribute("project"),
 			"organization": {
 				MarkdownDescription: "Organization that the project belongs to",
 				Computed:            true,
@@ -66,6 +69,18 @@ type projectResourceData struct {
 	Description  types.String `tfsdk:"description"`
 	Id           types.String `tfsdk:"id"`
 	Organization types.String `tfsdk:"organization"`
+
+	Settings           *projectSettingsData `tfsdk:"settings"`
+	DeletionProtection types.Bool           `tfsdk:"deletion_protection"`
+}
+
+func (d *projectResourceData) fromSDK(project project) {
+	d.Name = types.String{Value: project.Name}
+	d.Key = types.String{Value: project.Key}
+	d.Description = types.String{Value: project.Description}
+	d.Organization = types.String{Value: project.Organization}
+	d.Id = types.String{Value: project.Id}
+	d.Settings = projectSettingsFromSDK(project)
 }
 
 type projectResource struct {
@@ -90,21 +105,24 @@ func (r projectResource) Create(ctx context.Context, req tfsdk.CreateResourceReq
 		return
 	}
 
-	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerCreate(ctx, devcyclem.CreateProjectDto{
+	created, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerCreate(ctx, devcyclem.CreateProjectDto{
 		Name:        data.Name.Value,
 		Key:         strings.ToLower(data.Key.Value),
 		Description: data.Description.Value,
 	})
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
+	tflog.Trace(ctx, "Created a project with id %s", created.Id)
+
+	// Settings cannot be set when creating a project.
+	project, httpResponse, err := r.provider.updateProjectSettings(ctx, created.Key, data.Settings, nil)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
 
-	tflog.Trace(ctx, "Created a project with id %s", project.Id)
+	data.fromSDK(project)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -126,15 +144,17 @@ func (r projectResource) Read(ctx context.Context, req tfsdk.ReadResourceRequest
 		return
 	}
 
-	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerFindOne(ctx, data.Key.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	project, httpResponse, err := r.provider.getProject(ctx, data.Key.Value)
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "project no longer exists in DevCycle, removing it from state", "key", data.Key.Value)
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
+	data.fromSDK(project)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -149,26 +169,33 @@ func (r projectResource) Update(ctx context.Context, req tfsdk.UpdateResourceReq
 		)
 		return
 	}
+	var state projectResourceData
 	diags := req.Plan.Get(ctx, &data)
 	resp.Diagnostics.Append(diags...)
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	project, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerUpdate(ctx, devcyclem.UpdateProjectDto{
+	updated, httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerUpdate(ctx, devcyclem.UpdateProjectDto{
 		Name:        data.Name.Value,
 		Key:         data.Key.Value,
 		Description: data.Description.Value,
 	}, data.Key.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
+		return
+	}
+
+	// Only the changed settings are sent, so that the others keep the value set
+	// in DevCycle, even when it changed since the last refresh.
+	project, httpResponse, err := r.provider.updateProjectSettings(ctx, updated.Key, data.Settings, state.Settings)
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 
-	data.Name = types.String{Value: project.Name}
-	data.Key = types.String{Value: project.Key}
-	data.Organization = types.String{Value: project.Organization}
-	data.Id = types.String{Value: project.Id}
+	data.fromSDK(project)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -190,8 +217,12 @@ func (r projectResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 		return
 	}
 
+	if checkDeletionProtection(data.DeletionProtection, "project", data.Key.Value, &resp.Diagnostics) {
+		return
+	}
+
 	httpResponse, err := r.provider.MgmtClient.ProjectsApi.ProjectsControllerRemove(ctx, data.Key.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -201,5 +232,20 @@ func (r projectResource) Delete(ctx context.Context, req tfsdk.DeleteResourceReq
 }
 
 func (r projectResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	if req.ID == "" || strings.Contains(req.ID, "/") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	project, httpResponse, err := r.provider.getProject(ctx, req.ID)
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	data := projectResourceData{DeletionProtection: types.Bool{Null: true}}
+	data.fromSDK(project)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"context"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -25,6 +27,62 @@ func TestAccProjectResource(t *testing.T) {
 					resource.TestCheckResourceAttr("devcycle_project.test", "description", "Terraform acceptance testing-edit"),
 				),
 			},
+			{
+				ResourceName:      "devcycle_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     testAccProjectResourceKey,
+				ImportStateCheck:  testAccCheckImportedAttributeNull("deletion_protection"),
+			},
+			// Deleted outside of Terraform
+			{
+				PreConfig: func() {
+					_, err := testAccMgmtClient(t).ProjectsApi.ProjectsControllerRemove(context.Background(), testAccProjectResourceKey)
+					if err != nil {
+						t.Fatalf("unable to delete project: %s", err)
+					}
+				},
+				Config:             testAccProjectResourceConfigEdit,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccProjectResourceConfigEdit,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_project.test", "key", testAccProjectResourceKey),
+				),
+			},
+			// Settings
+			{
+				Config: testAccProjectResourceConfigSettings,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_project.test", "settings.color", "#5a8f3c"),
+					resource.TestCheckResourceAttr("devcycle_project.test", "settings.edge_db.enabled", "true"),
+					resource.TestCheckResourceAttr("devcycle_project.test", "settings.opt_in.title", "Beta features"),
+				),
+			},
+			// Removed settings keep their value
+			{
+				Config: testAccProjectResourceConfigEdit,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_project.test", "settings.edge_db.enabled", "true"),
+				),
+			},
+			// Deletion protection
+			{
+				Config: testAccProjectResourceConfigProtected,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("devcycle_project.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccProjectResourceConfigProtected,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("Deletion Protection Enabled"),
+			},
+			{
+				Config: testAccProjectResourceConfigEdit,
+			},
 			{
 				Config:  testAccProjectResourceConfig,
 				Destroy: true,
@@ -50,3 +108,30 @@ resource "devcycle_project" "test" {
   description = "Terraform acceptance testing-edit"
 }
 `
+
+var testAccProjectResourceConfigProtected = `
+resource "devcycle_project" "test" {
+  name = "TerraformAccTest` + randString + `"
+  key = "` + testAccProjectResourceKey + `"
+  description = "Terraform acceptance testing-edit"
+  deletion_protection = true
+}
+`
+
+var testAccProjectResourceConfigSettings = `
+resource "devcycle_project" "test" {
+  name = "TerraformAccTest` + randString + `"
+  key = "` + testAccProjectResourceKey + `"
+  description = "Terraform acceptance testing-edit"
+  settings = {
+    color = "#5a8f3c"
+    edge_db = {
+      enabled = true
+    }
+    opt_in = {
+      enabled = true
+      title = "Beta features"
+    }
+  }
+}
+`
@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// project is a project as returned by the management API, including the
+// settings that the generated client does not model.
+type project struct {
+	devcyclem.Project
+	Color    string           `json:"color"`
+	Settings *projectSettings `json:"settings"`
+}
+
+// projectSettings are the project settings modelled by the provider. Every
+// field is optional, so that a PATCH request only changes the settings it
+// includes.
+type projectSettings struct {
+	EdgeDB                  *projectEdgeDBSettings                  `json:"edgeDB,omitempty"`
+	OptIn                   *projectOptInSettings                   `json:"optIn,omitempty"`
+	SDKTypeVisibility       *projectSDKTypeVisibilitySettings       `json:"sdkTypeVisibility,omitempty"`
+	LifeCycle               *projectLifeCycleSettings               `json:"lifeCycle,omitempty"`
+	Staleness               *projectStalenessSettings               `json:"staleness,omitempty"`
+	FeatureApprovalWorkflow *projectFeatureApprovalWorkflowSettings `json:"featureApprovalWorkflow,omitempty"`
+}
+
+type projectEdgeDBSettings struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type projectOptInSettings struct {
+	Enabled     *bool                      `json:"enabled,omitempty"`
+	Title       *string                    `json:"title,omitempty"`
+	Description *string                    `json:"description,omitempty"`
+	ImageURL    *string                    `json:"imageURL,omitempty"`
+	Colors      *projectOptInColorSettings `json:"colors,omitempty"`
+}
+
+type projectOptInColorSettings struct {
+	Primary   *string `json:"primary,omitempty"`
+	Secondary *string `json:"secondary,omitempty"`
+}
+
+type projectSDKTypeVisibilitySettings struct {
+	EnabledInFeatureSettings *bool `json:"enabledInFeatureSettings,omitempty"`
+}
+
+type projectLifeCycleSettings struct {
+	DisableCodeRefChecks *bool `json:"disableCodeRefChecks,omitempty"`
+}
+
+type projectStalenessSettings struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+type projectFeatureApprovalWorkflowSettings struct {
+	Enabled              *bool    `json:"enabled,omitempty"`
+	AllowPublisherBypass *bool    `json:"allowPublisherBypass,omitempty"`
+	DefaultReviewers     []string `json:"defaultReviewers,omitempty"`
+}
+
+// updateProjectSettingsDto updates the custom color and settings of a project.
+type updateProjectSettingsDto struct {
+	Color    *string          `json:"color,omitempty"`
+	Settings *projectSettings `json:"settings,omitempty"`
+}
+
+func projectPath(key string) string {
+	return fmt.Sprintf("/v1/projects/%s", url.PathEscape(key))
+}
+
+// getProject reads a project with its settings.
+func (p provider) getProject(ctx context.Context, key string) (project, *http.Response, error) {
+	var ret project
+	httpResponse, err := p.mgmtRequest(ctx, http.MethodGet, projectPath(key), nil, &ret)
+	return ret, httpResponse, err
+}
+
+// updateProjectSettings sends the settings that differ from prior, which is nil
+// when the project was just created, and returns the updated project. The
+// project is only read when no setting changed.
+func (p provider) updateProjectSettings(ctx context.Context, key string, settings, prior *projectSettingsData) (project, *http.Response, error) {
+	body, changed := settings.toSDK(prior)
+	if !changed {
+		return p.getProject(ctx, key)
+	}
+	var ret project
+	httpResponse, err := p.mgmtRequest(ctx, http.MethodPatch, projectPath(key), body, &ret)
+	return ret, httpResponse, err
+}
+
+// projectSettingsAttribute returns the schema of the project settings. The
+// resource keeps the value set in DevCycle for every setting that is not
+// configured, while the data source only reads them.
+func projectSettingsAttribute(readOnly bool) tfsdk.Attribute {
+	setting := func(attribute tfsdk.Attribute) tfsdk.Attribute {
+		attribute.Computed = true
+		if readOnly {
+			attribute.Validators = nil
+			return attribute
+		}
+		attribute.Optional = true
+		attribute.PlanModifiers = tfsdk.AttributePlanModifiers{
+			tfsdk.UseStateForUnknown(),
+		}
+		return attribute
+	}
+	nested := func(description string, attributes map[string]tfsdk.Attribute) tfsdk.Attribute {
+		return setting(tfsdk.Attribute{
+			MarkdownDescription: description,
+			Attributes:          tfsdk.SingleNestedAttributes(attributes),
+		})
+	}
+	value := func(description string, attrType attr.Type, validators ...tfsdk.AttributeValidator) tfsdk.Attribute {
+		return setting(tfsdk.Attribute{
+			MarkdownDescription: description,
+			Type:                attrType,
+			Validators:          validators,
+		})
+	}
+
+	description := "Project Settings"
+	if !readOnly {
+		description += ". Settings that are not configured keep the value set in DevCycle, and only the changed settings are sent when updating the project."
+	}
+	return nested(description, map[string]tfsdk.Attribute{
+		"color": value("Custom color of the project in the DevCycle dashboard, as a hex color code", types.StringType, hexColorValidator{}),
+		"edge_db": nested("EdgeDB settings", map[string]tfsdk.Attribute{
+			"enabled": value("Whether EdgeDB is enabled, storing user data for use in targeting", types.BoolType),
+		}),
+		"opt_in": nested("Opt-In settings, letting users opt in to features", map[string]tfsdk.Attribute{
+			"enabled":         value("Whether users can opt in to features", types.BoolType),
+			"title":           value("Title of the Opt-In page", types.StringType),
+			"description":     value("Description of the Opt-In page", types.StringType),
+			"image_url":       value("URL of the image shown on the Opt-In page", types.StringType, urlValidator{}),
+			"primary_color":   value("Primary color of the Opt-In page, as a hex color code", types.StringType, hexColorValidator{}),
+			"secondary_color": value("Secondary color of the Opt-In page, as a hex color code", types.StringType, hexColorValidator{}),
+		}),
+		"sdk_type_visibility": nested("SDK type visibility settings", map[string]tfsdk.Attribute{
+			"enabled_in_feature_settings": value("Whether the SDK types a variable is visible to can be set in the feature settings", types.BoolType),
+		}),
+		"life_cycle": nested("Feature lifecycle settings", map[string]tfsdk.Attribute{
+			"disable_code_ref_checks": value("Whether code references are ignored when completing or archiving features", types.BoolType),
+		}),
+		"stale_features": nested("Stale feature settings", map[string]tfsdk.Attribute{
+			"enabled": value("Whether features are flagged as stale", types.BoolType),
+		}),
+		"feature_approval_workflow": nested("Feature approval workflow settings", map[string]tfsdk.Attribute{
+			"enabled":                value("Whether changes to features in protected environments require an approval", types.BoolType),
+			"allow_publisher_bypass": value("Whether publishers can apply changes without an approval", types.BoolType),
+			"default_reviewers":      value("IDs of the users requested to review changes by default", types.ListType{ElemType: types.StringType}),
+		}),
+	})
+}
+
+// projectSettingsData holds the project settings. Settings returned by the API
+// that are not modelled are ignored.
+type projectSettingsData struct {
+	Color                   types.String                          `tfsdk:"color"`
+	EdgeDB                  *projectEdgeDBSettingsData            `tfsdk:"edge_db"`
+	OptIn                   *projectOptInSettingsData             `tfsdk:"opt_in"`
+	SDKTypeVisibility       *projectSDKTypeVisibilitySettingsData `tfsdk:"sdk_type_visibility"`
+	LifeCycle               *projectLifeCycleSettingsData         `tfsdk:"life_cycle"`
+	StaleFeatures           *projectStaleFeaturesSettingsData     `tfsdk:"stale_features"`
+	FeatureApprovalWorkflow *projectFeatureApprovalSettingsData   `tfsdk:"feature_approval_workflow"`
+}
+
+type projectEdgeDBSettingsData struct {
+	Enabled types.Bool `tfsdk:"enabled"`
+}
+
+type projectOptInSettingsData struct {
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Title          types.String `tfsdk:"title"`
+	Description    types.String `tfsdk:"description"`
+	ImageURL       types.String `tfsdk:"image_url"`
+	PrimaryColor   types.String `tfsdk:"primary_color"`
+	SecondaryColor types.String `tfsdk:"secondary_color"`
+}
+
+type projectSDKTypeVisibilitySettingsData struct {
+	EnabledInFeatureSettings types.Bool `tfsdk:"enabled_in_feature_settings"`
+}
+
+type projectLifeCycleSettingsData struct {
+	DisableCodeRefChecks types.Bool `tfsdk:"disable_code_ref_checks"`
+}
+
+type projectStaleFeaturesSettingsData struct {
+	Enabled types.Bool `tfsdk:"enabled"`
+}
+
+type projectFeatureApprovalSettingsData struct {
+	Enabled              types.Bool `tfsdk:"enabled"`
+	AllowPublisherBypass types.Bool `tfsdk:"allow_publisher_bypass"`
+	DefaultReviewers     types.List `tfsdk:"default_reviewers"`
+}
+
+func projectSettingsFromSDK(project project) *projectSettingsData {
+	settings := project.Settings
+	if settings == nil {
+		settings = &projectSettings{}
+	}
+	edgeDB := settings.EdgeDB
+	if edgeDB == nil {
+		edgeDB = &projectEdgeDBSettings{}
+	}
+	optIn := settings.OptIn
+	if optIn == nil {
+		optIn = &projectOptInSettings{}
+	}
+	colors := optIn.Colors
+	if colors == nil {
+		colors = &projectOptInColorSettings{}
+	}
+	sdkTypeVisibility := settings.SDKTypeVisibility
+	if sdkTypeVisibility == nil {
+		sdkTypeVisibility = &projectSDKTypeVisibilitySettings{}
+	}
+	lifeCycle := settings.LifeCycle
+	if lifeCycle == nil {
+		lifeCycle = &projectLifeCycleSettings{}
+	}
+	staleness := settings.Staleness
+	if staleness == nil {
+		staleness = &projectStalenessSettings{}
+	}
+	approval := settings.FeatureApprovalWorkflow
+	if approval == nil {
+		approval = &projectFeatureApprovalWorkflowSettings{}
+	}
+
+	reviewers := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, reviewer := range approval.DefaultReviewers {
+		reviewers.Elems = append(reviewers.Elems, types.String{Value: reviewer})
+	}
+	return &projectSettingsData{
+		Color: types.String{Value: project.Color},
+		EdgeDB: &projectEdgeDBSettingsData{
+			Enabled: boolFromSDK(edgeDB.Enabled),
+		},
+		OptIn: &projectOptInSettingsData{
+			Enabled:        boolFromSDK(optIn.Enabled),
+			Title:          stringFromSDK(optIn.Title),
+			Description:    stringFromSDK(optIn.Description),
+			ImageURL:       stringFromSDK(optIn.ImageURL),
+			PrimaryColor:   stringFromSDK(colors.Primary),
+			SecondaryColor: stringFromSDK(colors.Secondary),
+		},
+		SDKTypeVisibility: &projectSDKTypeVisibilitySettingsData{
+			EnabledInFeatureSettings: boolFromSDK(sdkTypeVisibility.EnabledInFeatureSettings),
+		},
+		LifeCycle: &projectLifeCycleSettingsData{
+			DisableCodeRefChecks: boolFromSDK(lifeCycle.DisableCodeRefChecks),
+		},
+		StaleFeatures: &projectStaleFeaturesSettingsData{
+			Enabled: boolFromSDK(staleness.Enabled),
+		},
+		FeatureApprovalWorkflow: &projectFeatureApprovalSettingsData{
+			Enabled:              boolFromSDK(approval.Enabled),
+			AllowPublisherBypass: boolFromSDK(approval.AllowPublisherBypass),
+			DefaultReviewers:     reviewers,
+		},
+	}
+}
+
+// toSDK returns the request updating the settings that differ from prior,
+// and whether there is any. Each group of settings is sent as a whole when
+// one of its values changed, leaving out the values that are not configured.
+func (s *projectSettingsData) toSDK(prior *projectSettingsData) (updateProjectSettingsDto, bool) {
+	var body updateProjectSettingsDto
+	if s == nil {
+		return body, false
+	}
+	if prior == nil {
+		prior = &projectSettingsData{}
+	}
+	settings := &projectSettings{}
+	changed := false
+	settingChanged := func(value, priorValue interface{}) bool {
+		if reflect.ValueOf(value).IsNil() || reflect.DeepEqual(value, priorValue) {
+			return false
+		}
+		changed = true
+		return true
+	}
+
+	if !s.Color.Equal(prior.Color) {
+		body.Color = stringToSDK(s.Color)
+		changed = changed || body.Color != nil
+	}
+	if settingChanged(s.EdgeDB, prior.EdgeDB) {
+		settings.EdgeDB = &projectEdgeDBSettings{
+			Enabled: boolToSDK(s.EdgeDB.Enabled),
+		}
+	}
+	if settingChanged(s.OptIn, prior.OptIn) {
+		settings.OptIn = &projectOptInSettings{
+			Enabled:     boolToSDK(s.OptIn.Enabled),
+			Title:       stringToSDK(s.OptIn.Title),
+			Description: stringToSDK(s.OptIn.Description),
+			ImageURL:    stringToSDK(s.OptIn.ImageURL),
+		}
+		if primary, secondary := stringToSDK(s.OptIn.PrimaryColor), stringToSDK(s.OptIn.SecondaryColor); primary != nil || secondary != nil {
+			settings.OptIn.Colors = &projectOptInColorSettings{Primary: primary, Secondary: secondary}
+		}
+	}
+	if settingChanged(s.SDKTypeVisibility, prior.SDKTypeVisibility) {
+		settings.SDKTypeVisibility = &projectSDKTypeVisibilitySettings{
+			EnabledInFeatureSettings: boolToSDK(s.SDKTypeVisibility.EnabledInFeatureSettings),
+		}
+	}
+	if settingChanged(s.LifeCycle, prior.LifeCycle) {
+		settings.LifeCycle = &projectLifeCycleSettings{
+			DisableCodeRefChecks: boolToSDK(s.LifeCycle.DisableCodeRefChecks),
+		}
+	}
+	if settingChanged(s.StaleFeatures, prior.StaleFeatures) {
+		settings.Staleness = &projectStalenessSettings{
+			Enabled: boolToSDK(s.StaleFeatures.Enabled),
+		}
+	}
+	if settingChanged(s.FeatureApprovalWorkflow, prior.FeatureApprovalWorkflow) {
+		approval := s.FeatureApprovalWorkflow
+		settings.FeatureApprovalWorkflow = &projectFeatureApprovalWorkflowSettings{
+			Enabled:              boolToSDK(approval.Enabled),
+			AllowPublisherBypass: boolToSDK(approval.AllowPublisherBypass),
+		}
+		if !approval.DefaultReviewers.Null && !approval.DefaultReviewers.Unknown {
+			settings.FeatureApprovalWorkflow.DefaultReviewers = []string{}
+			for _, reviewer := range approval.DefaultReviewers.Elems {
+				if reviewer, ok := reviewer.(types.String); ok {
+					settings.FeatureApprovalWorkflow.DefaultReviewers = append(settings.FeatureApprovalWorkflow.DefaultReviewers, reviewer.Value)
+				}
+			}
+		}
+	}
+
+	if !reflect.DeepEqual(settings, &projectSettings{}) {
+		body.Settings = settings
+	}
+	return body, changed
+}
+
+func boolFromSDK(value *bool) types.Bool {
+	if value == nil {
+		return types.Bool{Value: false}
+	}
+	return types.Bool{Value: *value}
+}
+
+func stringFromSDK(value *string) types.String {
+	if value == nil {
+		return types.String{Value: ""}
+	}
+	return types.String{Value: *value}
+}
+
+// boolToSDK returns nil for values that are not configured, so that they are
+// left out of requests.
+func boolToSDK(value types.Bool) *bool {
+	if value.Null || value.Unknown {
+		return nil
+	}
+	return &value.Value
+}
+
+// stringToSDK returns nil for values that are not configured, so that they are
+// left out of requests.
+func stringToSDK(value types.String) *string {
+	if value.Null || value.Unknown {
+		return nil
+	}
+	return &value.Value
+}
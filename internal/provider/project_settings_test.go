@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestProjectSettingsToSDK(t *testing.T) {
+	var proj project
+	err := json.Unmarshal([]byte(`{
+		"_id": "project-id",
+		"key": "project",
+		"color": "#123456",
+		"settings": {
+			"edgeDB": {"enabled": false},
+			"optIn": {"enabled": true, "title": "Beta", "colors": {"primary": "#000000"}, "unknownSetting": 1},
+			"featureApprovalWorkflow": {"enabled": true, "defaultReviewers": ["user-1"]}
+		}
+	}`), &proj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := projectSettingsFromSDK(proj)
+	if !state.OptIn.Enabled.Value || state.OptIn.PrimaryColor.Value != "#000000" || state.LifeCycle.DisableCodeRefChecks.Value || len(state.FeatureApprovalWorkflow.DefaultReviewers.Elems) != 1 {
+		t.Fatalf("unexpected settings %+v", state)
+	}
+
+	if _, changed := projectSettingsFromSDK(proj).toSDK(state); changed {
+		t.Error("expected unchanged settings not to be sent")
+	}
+
+	plan := projectSettingsFromSDK(proj)
+	plan.EdgeDB.Enabled = types.Bool{Value: true}
+	body, changed := plan.toSDK(state)
+	if !changed {
+		t.Fatal("expected changed settings to be sent")
+	}
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"settings":{"edgeDB":{"enabled":true}}}`; string(encoded) != expected {
+		t.Errorf("expected only the changed settings %s, got %s", expected, encoded)
+	}
+
+	// Settings that are not configured are left out when the project is created.
+	config := &projectSettingsData{
+		Color: types.String{Null: true},
+		OptIn: &projectOptInSettingsData{
+			Enabled:        types.Bool{Value: true},
+			Title:          types.String{Null: true},
+			Description:    types.String{Null: true},
+			ImageURL:       types.String{Null: true},
+			PrimaryColor:   types.String{Null: true},
+			SecondaryColor: types.String{Value: "#ffffff"},
+		},
+	}
+	body, changed = config.toSDK(nil)
+	if !changed {
+		t.Fatal("expected configured settings to be sent")
+	}
+	encoded, err = json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := `{"settings":{"optIn":{"enabled":true,"colors":{"secondary":"#ffffff"}}}}`; string(encoded) != expected {
+		t.Errorf("expected %s, got %s", expected, encoded)
+	}
+}
@@ -2,27 +2,51 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
 	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
+	"github.com/devcyclehq/terraform-provider-devcycle/internal/dvc_http"
 	"github.com/devcyclehq/terraform-provider-devcycle/internal/dvc_oauth"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
+var apiUrl = "https://api.devcycle.com"
 var bucketingApiUrl = "https://bucketing-api.devcycle.com"
+var configCDNUrl = "https://config-cdn.devcycle.com"
 
 // provider satisfies the tfsdk.Provider interface and usually is included
 // with all Resource and DataSource implementations.
 type provider struct {
-	MgmtClient          *dvc_mgmt.DVCClient
-	ServerClient        *dvc_server.DVCClient
-	AccessToken         string
+	MgmtClient   *dvc_mgmt.DVCClient
+	MgmtConfig   *dvc_mgmt.Configuration
+	ServerClient *dvc_server.DVCClient
+	AccessToken  string
+	// AuthSource describes where AccessToken came from, for diagnostics.
+	AuthSource          string
 	ServerClientContext context.Context
 
+	// evaluatedFeatures caches the features bucketed for each user by
+	// ServerClient. It is replaced along with the client.
+	evaluatedFeatures *evaluatedFeaturesCache
+
+	// bucketingConfigListener serves bucketing_config_file to the server SDK.
+	// It is closed when the provider is configured again.
+	bucketingConfigListener net.Listener
+
+	// PreventProductionDestroy refuses to delete environments of type
+	// production.
+	PreventProductionDestroy bool
+
 	// configured is set to true at the end of the Configure method.
 	// This can be used in Resource and DataSource implementations to verify
 	// that the provider was previously configured.
@@ -36,9 +60,149 @@ type provider struct {
 
 // providerData can be used to store data from the Terraform configuration.
 type providerData struct {
-	ServerSDKToken types.String `tfsdk:"server_sdk_token"`
-	ClientId       types.String `tfsdk:"client_id"`
-	ClientSecret   types.String `tfsdk:"client_secret"`
+	ServerSDKToken       types.String `tfsdk:"server_sdk_token"`
+	ServerSDKEnvironment types.String `tfsdk:"server_sdk_environment"`
+	AccessToken          types.String `tfsdk:"access_token"`
+	ClientId             types.String `tfsdk:"client_id"`
+	ClientSecret         types.String `tfsdk:"client_secret"`
+
+	LocalBucketing      types.Bool   `tfsdk:"local_bucketing"`
+	BucketingConfigFile types.String `tfsdk:"bucketing_config_file"`
+
+	ApiUrl          types.String `tfsdk:"api_url"`
+	AuthUrl         types.String `tfsdk:"auth_url"`
+	BucketingApiUrl types.String `tfsdk:"bucketing_api_url"`
+	ConfigCDNUrl    types.String `tfsdk:"config_cdn_url"`
+
+	MaxRetries   types.Int64 `tfsdk:"max_retries"`
+	RetryMaxWait types.Int64 `tfsdk:"retry_max_wait"`
+
+	PreventProductionDestroy types.Bool `tfsdk:"prevent_production_destroy"`
+}
+
+// urlSetting resolves a URL provider setting from its attribute, then its
+// environment variable, then its default, validating values from the
+// environment which are not covered by the attribute validator.
+func urlSetting(value types.String, attribute, envVar, defaultValue string, diags *diag.Diagnostics) string {
+	if value.Value != "" {
+		return value.Value
+	}
+	if env := os.Getenv(envVar); env != "" {
+		if err := validateURL(env); err != nil {
+			diags.AddAttributeError(
+				tftypes.NewAttributePath().WithAttributeName(attribute),
+				"Invalid URL",
+				fmt.Sprintf("The %s environment variable is invalid: %s", envVar, err),
+			)
+		}
+		return env
+	}
+	return defaultValue
+}
+
+// resolveAccessToken picks the management API access token, in order of
+// precedence: the access_token attribute, client credentials from attributes or
+// the environment, then the DEVCYCLE_ACCESS_TOKEN environment variable. It
+// returns the token and a description of its source. Client credentials also
+// return the token source that keeps the token refreshed.
+func resolveAccessToken(ctx context.Context, data providerData, authUrl string, client *http.Client, diags *diag.Diagnostics) (string, string, *dvc_oauth.TokenSource) {
+	if data.AccessToken.Value != "" {
+		return data.AccessToken.Value, "access_token attribute", nil
+	}
+
+	clientId, clientSecret := data.ClientId.Value, data.ClientSecret.Value
+	fromAttributes := clientId != "" || clientSecret != ""
+	source := "client_id and client_secret attributes"
+	if fromAttributes && (clientId == "" || clientSecret == "") {
+		missing := "client_secret"
+		if clientId == "" {
+			missing = "client_id"
+		}
+		diags.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName(missing),
+			"Missing Client Credentials",
+			"Both client_id and client_secret must be set to authenticate with client credentials.",
+		)
+		return "", source, nil
+	}
+	if !fromAttributes {
+		clientId, clientSecret = os.Getenv("DEVCYCLE_CLIENT_ID"), os.Getenv("DEVCYCLE_CLIENT_SECRET")
+		source = "DEVCYCLE_CLIENT_ID and DEVCYCLE_CLIENT_SECRET environment variables"
+	}
+	if clientId != "" && clientSecret != "" {
+		tokenSource := dvc_oauth.NewTokenSource(authUrl, clientId, clientSecret)
+		tokenSource.HTTPClient = client
+		token, err := tokenSource.Token(ctx)
+		if err != nil {
+			addAuthErrorDiagnostic(err, source, fromAttributes, authUrl, diags)
+			return "", source, nil
+		}
+		return token, source, tokenSource
+	}
+
+	if token := os.Getenv("DEVCYCLE_ACCESS_TOKEN"); token != "" {
+		return token, "DEVCYCLE_ACCESS_TOKEN environment variable", nil
+	}
+	return "", "", nil
+}
+
+// retryTransport builds the retrying transport shared by the management API
+// and OAuth clients from the max_retries and retry_max_wait settings.
+func retryTransport(data providerData, diags *diag.Diagnostics) *dvc_http.RetryTransport {
+	maxRetries := int64(dvc_http.DefaultMaxRetries)
+	if !data.MaxRetries.Null && !data.MaxRetries.Unknown {
+		maxRetries = data.MaxRetries.Value
+	}
+	maxWait := dvc_http.DefaultMaxWait
+	if !data.RetryMaxWait.Null && !data.RetryMaxWait.Unknown {
+		maxWait = time.Duration(data.RetryMaxWait.Value) * time.Second
+	}
+
+	if maxRetries < 0 {
+		diags.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("max_retries"),
+			"Invalid Retry Setting",
+			"max_retries must be zero or greater.",
+		)
+	}
+	if maxWait <= 0 {
+		diags.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("retry_max_wait"),
+			"Invalid Retry Setting",
+			"retry_max_wait must be greater than zero. Set max_retries to 0 to disable retries.",
+		)
+	}
+	return dvc_http.NewRetryTransport(nil, int(maxRetries), maxWait)
+}
+
+// addAuthErrorDiagnostic describes a failed client credentials exchange,
+// pointing at the provider attribute most likely to be at fault.
+func addAuthErrorDiagnostic(err error, source string, fromAttributes bool, authUrl string, diags *diag.Diagnostics) {
+	summary := "Unable to authenticate with DevCycle"
+	detail := fmt.Sprintf("Exchanging the client credentials from the %s for an access token at %s failed: %s", source, authUrl, err)
+	attribute := ""
+
+	var networkErr *dvc_oauth.NetworkError
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+	case errors.As(err, &networkErr), errors.Is(err, dvc_oauth.ErrMalformedResponse):
+		attribute = "auth_url"
+		detail += "\n\nCheck that auth_url, or the DEVCYCLE_AUTH_URL environment variable, points at the DevCycle OAuth token endpoint."
+	case errors.Is(err, dvc_oauth.ErrInvalidClient):
+		summary = "Invalid DevCycle Client Credentials"
+		attribute = "client_secret"
+		detail += "\n\nCheck the client ID and secret in your DevCycle organization settings."
+	case errors.Is(err, dvc_oauth.ErrUnauthorizedAudience):
+		summary = "DevCycle Client Not Authorized"
+		attribute = "client_id"
+		detail += "\n\nThe client credentials are valid but cannot access the DevCycle management API. Use API credentials from your DevCycle organization settings."
+	}
+
+	if attribute == "" || !fromAttributes && attribute != "auth_url" {
+		diags.AddError(summary, detail)
+		return
+	}
+	diags.AddAttributeError(tftypes.NewAttributePath().WithAttributeName(attribute), summary, detail)
 }
 
 func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderRequest, resp *tfsdk.ConfigureProviderResponse) {
@@ -51,57 +215,155 @@ func (p *provider) Configure(ctx context.Context, req tfsdk.ConfigureProviderReq
 		return
 	}
 
-	if data.ClientId.Value != "" && data.ClientSecret.Value != "" {
-		auth, err := dvc_oauth.GetAuthToken(data.ClientId.Value, data.ClientSecret.Value)
-		if err != nil {
-			p.configured = false
-			return
-		}
-		p.AccessToken = auth.AccessToken
-	} else {
-		clientId := os.Getenv("DEVCYCLE_CLIENT_ID")
-		clientSecret := os.Getenv("DEVCYCLE_CLIENT_SECRET")
-		if clientId != "" && clientSecret != "" {
-			auth, err := dvc_oauth.GetAuthToken(clientId, clientSecret)
-			if err != nil {
-				p.configured = false
-				return
-			}
-			p.AccessToken = auth.AccessToken
-		}
+	mgmtApiUrl := urlSetting(data.ApiUrl, "api_url", "DEVCYCLE_API_URL", apiUrl, &resp.Diagnostics)
+	authUrl := urlSetting(data.AuthUrl, "auth_url", "DEVCYCLE_AUTH_URL", dvc_oauth.DefaultAuthURL, &resp.Diagnostics)
+	serverBucketingApiUrl := urlSetting(data.BucketingApiUrl, "bucketing_api_url", "DEVCYCLE_BUCKETING_API_URL", bucketingApiUrl, &resp.Diagnostics)
+	serverConfigCDNUrl := urlSetting(data.ConfigCDNUrl, "config_cdn_url", "DEVCYCLE_CONFIG_CDN_URL", configCDNUrl, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if data.ServerSDKToken.Value != "" {
-		p.ServerClientContext = context.WithValue(context.Background(), dvc_server.ContextAPIKey, dvc_server.APIKey{
-			Key: data.ServerSDKToken.Value,
-		})
-	} else {
-		p.ServerClientContext = context.WithValue(context.Background(), dvc_server.ContextAPIKey, dvc_server.APIKey{
-			Key: os.Getenv("DEVCYCLE_SERVER_TOKEN"),
-		})
+	transport := retryTransport(data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Token requests are POSTs, but repeating one only issues another token.
+	oauthTransport := *transport
+	oauthTransport.RetryPost = true
+
+	var tokenSource *dvc_oauth.TokenSource
+	p.AccessToken, p.AuthSource, tokenSource = resolveAccessToken(ctx, data, authUrl, &http.Client{Transport: &oauthTransport}, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		p.configured = false
+		return
 	}
 
 	config := dvc_mgmt.NewConfiguration()
-	config.AddDefaultHeader("Authorization", p.AccessToken)
+	if tokenSource != nil {
+		config.HTTPClient = &http.Client{Transport: &dvc_oauth.Transport{Source: tokenSource, Base: transport}}
+	} else {
+		config.HTTPClient = &http.Client{Transport: transport}
+		config.AddDefaultHeader("Authorization", p.AccessToken)
+	}
 	config.AddDefaultHeader("dvc-referrer", "terraform")
 	metadata := fmt.Sprintf("{\"dvc_terraform_provider_version\": \"%s\", \"terraform_version\": \"%s\"}", p.version, req.TerraformVersion)
 	config.AddDefaultHeader("dvc-referrer-metadata", metadata)
-	config.BasePath = "https://api.devcycle.com"
+	config.BasePath = mgmtApiUrl
 	config.UserAgent = "terraform-provider-devcycle"
+	p.MgmtConfig = config
 	p.MgmtClient = dvc_mgmt.NewAPIClient(config)
-	p.ServerClient, _ = dvc_server.NewDVCClient(os.Getenv("DEVCYCLE_SERVER_TOKEN"), &dvc_server.DVCOptions{
-		EnableEdgeDB:    true,
-		BucketingAPIURI: bucketingApiUrl,
+	p.PreventProductionDestroy = data.PreventProductionDestroy.Value
+
+	serverSDKToken := data.ServerSDKToken.Value
+	if serverSDKToken == "" {
+		serverSDKToken = os.Getenv("DEVCYCLE_SERVER_TOKEN")
+	}
+	if serverSDKToken == "" && data.ServerSDKEnvironment.Value != "" {
+		serverSDKToken = p.fetchServerSDKKey(ctx, data.ServerSDKEnvironment.Value, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	p.ServerClientContext = context.WithValue(context.Background(), dvc_server.ContextAPIKey, dvc_server.APIKey{
+		Key: serverSDKToken,
 	})
+
+	// Stop the polling and event flushing of a client from a previous
+	// configuration before replacing it.
+	if p.ServerClient != nil {
+		_ = p.ServerClient.Close()
+		p.ServerClient = nil
+	}
+	p.evaluatedFeatures = newEvaluatedFeaturesCache()
+	if p.bucketingConfigListener != nil {
+		_ = p.bucketingConfigListener.Close()
+		p.bucketingConfigListener = nil
+	}
+	if data.LocalBucketing.Value || data.BucketingConfigFile.Value != "" {
+		options := &dvc_server.DVCOptions{
+			ConfigCDNURI: serverConfigCDNUrl,
+		}
+		if data.BucketingConfigFile.Value != "" {
+			listener, err := serveBucketingConfigFile(data.BucketingConfigFile.Value)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					tftypes.NewAttributePath().WithAttributeName("bucketing_config_file"),
+					"Invalid Bucketing Config File",
+					fmt.Sprintf("Unable to load the bucketing config file: %s", err),
+				)
+				return
+			}
+			// The config is never refreshed and nothing can be sent from an offline run.
+			p.bucketingConfigListener = listener
+			options.ConfigCDNURI = "http://" + listener.Addr().String()
+			options.ConfigPollingIntervalMS = time.Hour
+			options.DisableAutomaticEventLogging = true
+			options.DisableCustomEventLogging = true
+		}
+		client, err := dvc_server.NewDVCClient(serverSDKToken, options)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to initialize local bucketing",
+				fmt.Sprintf("The DevCycle server SDK could not initialize local bucketing with the configured server SDK token: %s", err),
+			)
+			return
+		}
+		p.ServerClient = client
+	} else if serverSDKToken != "" {
+		client, err := dvc_server.NewDVCClient(serverSDKToken, &dvc_server.DVCOptions{
+			EnableEdgeDB:         true,
+			EnableCloudBucketing: true,
+			BucketingAPIURI:      serverBucketingApiUrl,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to initialize cloud bucketing",
+				fmt.Sprintf("The DevCycle server SDK could not be initialized with the configured server SDK token: %s", err),
+			)
+			return
+		}
+		p.ServerClient = client
+	} else {
+		// Managing resources does not need a server SDK token, so its absence
+		// is only reported by the data sources evaluating feature flags.
+		p.ServerClient = nil
+	}
 	p.configured = true
 }
 
+// fetchServerSDKKey reads a usable server SDK key of the environment identified
+// by project_key/environment_key.
+func (p *provider) fetchServerSDKKey(ctx context.Context, id string, diags *diag.Diagnostics) string {
+	path := tftypes.NewAttributePath().WithAttributeName("server_sdk_environment")
+	parts := strings.Split(id, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		diags.AddAttributeError(path, "Invalid Server SDK Environment", fmt.Sprintf("Expected format: project_key/environment_key. Got: %q", id))
+		return ""
+	}
+
+	environment, httpResponse, err := p.getEnvironment(ctx, parts[0], parts[1])
+	if handleDevCycleHTTP(err, httpResponse, p.AuthSource, diags) {
+		return ""
+	}
+	key, ok := usableSDKKey(sdkKeysOfType(environment.SdkKeys, "server"))
+	if !ok {
+		diags.AddAttributeError(path, "No Usable Server SDK Key", fmt.Sprintf("Environment %q has no server SDK key that is neither compromised nor invalidated.", id))
+		return ""
+	}
+	return key.Key
+}
+
 func (p *provider) GetResources(ctx context.Context) (map[string]tfsdk.ResourceType, diag.Diagnostics) {
 	return map[string]tfsdk.ResourceType{
-		"devcycle_project":     projectResourceType{},
-		"devcycle_environment": environmentResourceType{},
-		"devcycle_feature":     featureResourceType{},
-		"devcycle_variable":    variableResourceType{},
+		"devcycle_project":             projectResourceType{},
+		"devcycle_environment":         environmentResourceType{},
+		"devcycle_feature":             featureResourceType{},
+		"devcycle_variable":            variableResourceType{},
+		"devcycle_feature_targeting":   featureTargetingResourceType{},
+		"devcycle_audience":            audienceResourceType{},
+		"devcycle_custom_property":     customPropertyResourceType{},
+		"devcycle_environment_sdk_key": environmentSDKKeyResourceType{},
 	}, nil
 }
 
@@ -111,6 +373,11 @@ func (p *provider) GetDataSources(ctx context.Context) (map[string]tfsdk.DataSou
 		"devcycle_environment":                environmentDataSourceType{},
 		"devcycle_feature":                    featureDataSourceType{},
 		"devcycle_variable":                   variableDataSourceType{},
+		"devcycle_audience":                   audienceDataSourceType{},
+		"devcycle_custom_properties":          customPropertiesDataSourceType{},
+		"devcycle_evaluated_variable":         evaluatedVariableDataSourceType{},
+		"devcycle_evaluated_variables":        evaluatedVariablesDataSourceType{},
+		"devcycle_evaluated_features":         evaluatedFeaturesDataSourceType{},
 		"devcycle_evaluated_variable_boolean": evaluatedBoolVariableDataSourceType{},
 		"devcycle_evaluated_variable_string":  evaluatedStringVariableDataSourceType{},
 		"devcycle_evaluated_variable_number":  evaluatedNumberVariableDataSourceType{},
@@ -123,6 +390,12 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 		MarkdownDescription: "This provider allows you to manage DevCycle projects, environments, features, and variables. It uses the DevCycle API to manage these resources.  You can find more information about the DevCycle API [here](https://docs.devcycle.com/management-api/)." +
 			"\n\n" + "This provider is compatible with Terraform v1.0 and newer. Because of the way that authentication for the management api works - this provider will have access to manage all projects within a DevCycle org. Be careful!",
 		Attributes: map[string]tfsdk.Attribute{
+			"access_token": {
+				MarkdownDescription: "Pre-issued management API access token. Takes precedence over the client credentials. Can also be set with the `DEVCYCLE_ACCESS_TOKEN` environment variable, which has the lowest precedence.",
+				Optional:            true,
+				Sensitive:           true,
+				Type:                types.StringType,
+			},
 			"client_id": {
 				MarkdownDescription: "API Authentication Client ID. Found in your DevCycle account settings.",
 				Optional:            true,
@@ -141,6 +414,60 @@ func (p *provider) GetSchema(ctx context.Context) (tfsdk.Schema, diag.Diagnostic
 				Sensitive:           true,
 				Optional:            true,
 			},
+			"server_sdk_environment": {
+				Type:                types.StringType,
+				MarkdownDescription: "Environment to fetch a server SDK key from when `server_sdk_token` is not set, as `project_key/environment_key`. The most recent key that is neither compromised nor invalidated is read with the management API credentials when the provider is configured, so that it never has to be stored in the Terraform state.",
+				Optional:            true,
+			},
+			"api_url": {
+				Type:                types.StringType,
+				MarkdownDescription: "DevCycle management API URL. Can also be set with the `DEVCYCLE_API_URL` environment variable. Defaults to `https://api.devcycle.com`.",
+				Optional:            true,
+				Validators:          []tfsdk.AttributeValidator{urlValidator{}},
+			},
+			"auth_url": {
+				Type:                types.StringType,
+				MarkdownDescription: "DevCycle OAuth token URL used to exchange the client credentials. Can also be set with the `DEVCYCLE_AUTH_URL` environment variable. Defaults to `https://auth.devcycle.com/oauth/token`.",
+				Optional:            true,
+				Validators:          []tfsdk.AttributeValidator{urlValidator{}},
+			},
+			"bucketing_api_url": {
+				Type:                types.StringType,
+				MarkdownDescription: "DevCycle cloud bucketing API URL used to evaluate feature flags. Can also be set with the `DEVCYCLE_BUCKETING_API_URL` environment variable. Defaults to `https://bucketing-api.devcycle.com`.",
+				Optional:            true,
+				Validators:          []tfsdk.AttributeValidator{urlValidator{}},
+			},
+			"config_cdn_url": {
+				Type:                types.StringType,
+				MarkdownDescription: "DevCycle config CDN URL used by local bucketing. Can also be set with the `DEVCYCLE_CONFIG_CDN_URL` environment variable. Defaults to `https://config-cdn.devcycle.com`.",
+				Optional:            true,
+				Validators:          []tfsdk.AttributeValidator{urlValidator{}},
+			},
+			"local_bucketing": {
+				Type:                types.BoolType,
+				MarkdownDescription: "Evaluate feature flags locally with the server SDK's local bucketing instead of the cloud bucketing API. Defaults to `false`.",
+				Optional:            true,
+			},
+			"bucketing_config_file": {
+				Type:                types.StringType,
+				MarkdownDescription: "Path to a project config JSON file, as served by the DevCycle config CDN for the server SDK token. Implies `local_bucketing`, and no network access is required for evaluations. The server SDK token must still be set, but can be any value starting with `server`.",
+				Optional:            true,
+			},
+			"prevent_production_destroy": {
+				Type:                types.BoolType,
+				MarkdownDescription: "Refuse to delete environments of type `production`, including when they have to be replaced, regardless of their `deletion_protection`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"max_retries": {
+				Type:                types.Int64Type,
+				MarkdownDescription: "Maximum number of times a management API or authentication request is retried after being rate limited or failing with a transient error. Only safe and idempotent requests are retried on transient errors. Set to `0` to disable retries. Defaults to `3`.",
+				Optional:            true,
+			},
+			"retry_max_wait": {
+				Type:                types.Int64Type,
+				MarkdownDescription: "Maximum number of seconds to wait before a single retry, including waits requested by the API with a `Retry-After` header. Must be greater than `0`. Defaults to `30`.",
+				Optional:            true,
+			},
 		},
 	}, nil
 }
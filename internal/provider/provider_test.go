@@ -1,13 +1,23 @@
 package provider
 
 import (
+	"context"
+	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
 
+	dvc_mgmt "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/devcyclehq/terraform-provider-devcycle/internal/dvc_oauth"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -29,3 +39,112 @@ func testAccPreCheck(t *testing.T) {
 	t.Setenv("DEVCYCLE_ACCESS_TOKEN", os.Getenv("DEVCYCLE_ACCESS_TOKEN"))
 	t.Setenv("DEVCYCLE_SERVER_TOKEN", os.Getenv("DEVCYCLE_SERVER_TOKEN"))
 }
+
+// testAccMgmtClient returns a management API client authenticated like the
+// provider, to change DevCycle objects outside of Terraform.
+func testAccMgmtClient(t *testing.T) *dvc_mgmt.DVCClient {
+	token := os.Getenv("DEVCYCLE_ACCESS_TOKEN")
+	if clientId, clientSecret := os.Getenv("DEVCYCLE_CLIENT_ID"), os.Getenv("DEVCYCLE_CLIENT_SECRET"); clientId != "" && clientSecret != "" {
+		auth, err := dvc_oauth.GetAuthToken(clientId, clientSecret)
+		if err != nil {
+			t.Fatalf("unable to authenticate: %s", err)
+		}
+		token = auth.AccessToken
+	}
+
+	config := dvc_mgmt.NewConfiguration()
+	config.AddDefaultHeader("Authorization", token)
+	config.BasePath = apiUrl
+	return dvc_mgmt.NewAPIClient(config)
+}
+
+// testAccProjectScopedImportStateId builds the project_key/key import
+// identifier of a project scoped resource from its state.
+func testAccProjectScopedImportStateId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		return rs.Primary.Attributes["project_id"] + "/" + rs.Primary.Attributes["key"], nil
+	}
+}
+
+// testAccCheckImportedAttributeNull checks that an optional attribute is null
+// after import, so that configurations which omit it show no changes.
+func testAccCheckImportedAttributeNull(attribute string) resource.ImportStateCheckFunc {
+	return func(states []*terraform.InstanceState) error {
+		for _, state := range states {
+			if value, ok := state.Attributes[attribute]; ok {
+				return fmt.Errorf("expected %s to be null after import, got %q", attribute, value)
+			}
+		}
+		return nil
+	}
+}
+
+func TestResolveAccessToken(t *testing.T) {
+	auth := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token": "client-token", "expires_in": 3600, "token_type": "Bearer"}`)
+	}))
+	defer auth.Close()
+
+	credentials := providerData{
+		ClientId:     types.String{Value: "id"},
+		ClientSecret: types.String{Value: "secret"},
+	}
+	explicit := credentials
+	explicit.AccessToken = types.String{Value: "explicit-token"}
+
+	cases := []struct {
+		name          string
+		data          providerData
+		envCredential bool
+		token         string
+		source        string
+	}{
+		{"explicit token", explicit, true, "explicit-token", "access_token attribute"},
+		{"client credentials", credentials, false, "client-token", "client_id and client_secret attributes"},
+		{"environment client credentials", providerData{}, true, "client-token", "DEVCYCLE_CLIENT_ID and DEVCYCLE_CLIENT_SECRET environment variables"},
+		{"environment token", providerData{}, false, "env-token", "DEVCYCLE_ACCESS_TOKEN environment variable"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Setenv("DEVCYCLE_ACCESS_TOKEN", "env-token")
+			t.Setenv("DEVCYCLE_CLIENT_ID", "")
+			t.Setenv("DEVCYCLE_CLIENT_SECRET", "")
+			if c.envCredential {
+				t.Setenv("DEVCYCLE_CLIENT_ID", "env-id")
+				t.Setenv("DEVCYCLE_CLIENT_SECRET", "env-secret")
+			}
+
+			var diags diag.Diagnostics
+			token, source, _ := resolveAccessToken(context.Background(), c.data, auth.URL, auth.Client(), &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if token != c.token || source != c.source {
+				t.Errorf("expected %q from the %s, got %q from the %s", c.token, c.source, token, source)
+			}
+		})
+	}
+}
+
+func TestRetryTransportSettings(t *testing.T) {
+	var diags diag.Diagnostics
+	transport := retryTransport(providerData{
+		MaxRetries:   types.Int64{Value: 0},
+		RetryMaxWait: types.Int64{Value: 5},
+	}, &diags)
+	if diags.HasError() || transport.MaxRetries != 0 || transport.MaxWait != 5*time.Second {
+		t.Fatalf("unexpected transport %+v: %v", transport, diags)
+	}
+
+	retryTransport(providerData{
+		MaxRetries:   types.Int64{Null: true},
+		RetryMaxWait: types.Int64{Value: 0},
+	}, &diags)
+	if !diags.HasError() {
+		t.Error("expected a retry_max_wait of 0 to be rejected")
+	}
+}
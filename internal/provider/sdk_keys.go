@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sdkKey is an SDK key as returned by the management API, including the flags
+// that the generated client does not model.
+type sdkKey struct {
+	Key         string    `json:"key"`
+	CreatedAt   time.Time `json:"createdAt"`
+	Compromised bool      `json:"compromised"`
+	Invalidated bool      `json:"invalidated"`
+}
+
+type sdkKeys struct {
+	Mobile []sdkKey `json:"mobile"`
+	Client []sdkKey `json:"client"`
+	Server []sdkKey `json:"server"`
+}
+
+// environment is an environment as returned by the management API. Its SDK keys
+// and settings replace those of the generated model.
+type environment struct {
+	devcyclem.Environment
+	SdkKeys  *sdkKeys                   `json:"sdkKeys"`
+	Settings map[string]json.RawMessage `json:"settings"`
+}
+
+func environmentPath(project, key string) string {
+	return fmt.Sprintf("/v1/projects/%s/environments/%s", url.PathEscape(project), url.PathEscape(key))
+}
+
+// getEnvironment reads an environment with the flags of its SDK keys.
+func (p provider) getEnvironment(ctx context.Context, project, key string) (environment, *http.Response, error) {
+	var ret environment
+	httpResponse, err := p.mgmtRequest(ctx, http.MethodGet, environmentPath(project, key), nil, &ret)
+	return ret, httpResponse, err
+}
+
+// sdkKeysOfType returns the keys of the given type, one of sdkKeyTypeValues.
+func sdkKeysOfType(keys *sdkKeys, keyType string) []sdkKey {
+	if keys == nil {
+		return nil
+	}
+	switch keyType {
+	case "client":
+		return keys.Client
+	case "server":
+		return keys.Server
+	case "mobile":
+		return keys.Mobile
+	}
+	return nil
+}
+
+// usableSDKKey returns the most recent key that is neither compromised nor
+// invalidated.
+func usableSDKKey(keys []sdkKey) (sdkKey, bool) {
+	var ret sdkKey
+	found := false
+	for _, key := range keys {
+		if key.Compromised || key.Invalidated {
+			continue
+		}
+		if !found || key.CreatedAt.After(ret.CreatedAt) {
+			ret, found = key, true
+		}
+	}
+	return ret, found
+}
+
+var sdkKeyAttrTypes = map[string]attr.Type{
+	"key":         types.StringType,
+	"created_at":  types.StringType,
+	"compromised": types.BoolType,
+	"invalidated": types.BoolType,
+}
+
+// sdkKeysAttribute returns the schema of the SDK keys of one type.
+func sdkKeysAttribute(keyType string) tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: fmt.Sprintf("%s SDK keys for the environment", keyType),
+		Computed:            true,
+		Attributes: tfsdk.ListNestedAttributes(map[string]tfsdk.Attribute{
+			"key": {
+				MarkdownDescription: "SDK key",
+				Computed:            true,
+				Sensitive:           true,
+				Type:                types.StringType,
+			},
+			"created_at": {
+				MarkdownDescription: "Date the key was generated, in RFC3339 format",
+				Computed:            true,
+				Type:                types.StringType,
+			},
+			"compromised": {
+				MarkdownDescription: "Whether the key was flagged as compromised",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+			"invalidated": {
+				MarkdownDescription: "Whether the key was invalidated and is no longer accepted",
+				Computed:            true,
+				Type:                types.BoolType,
+			},
+		}, tfsdk.ListNestedAttributesOptions{}),
+	}
+}
+
+// environmentSDKKeys converts the SDK keys of an environment to the values of
+// the sdk_keys, server_sdk_keys, client_sdk_keys and mobile_sdk_keys
+// attributes. Lists are used as the keys are unknown in plans. Server keys are
+// left out when storeServerKeys is false.
+func environmentSDKKeys(keys *sdkKeys, storeServerKeys bool) (all, server, client, mobile types.List) {
+	if keys == nil {
+		keys = &sdkKeys{}
+	}
+	server = types.List{ElemType: types.ObjectType{AttrTypes: sdkKeyAttrTypes}, Null: true}
+	all = sdkKeyConvert(keys.Mobile, keys.Client)
+	if storeServerKeys {
+		server = sdkKeysToTF(keys.Server)
+		all = sdkKeyConvert(keys.Mobile, keys.Server, keys.Client)
+	}
+	return all, server, sdkKeysToTF(keys.Client), sdkKeysToTF(keys.Mobile)
+}
+
+func sdkKeysToTF(keys []sdkKey) types.List {
+	ret := types.List{ElemType: types.ObjectType{AttrTypes: sdkKeyAttrTypes}, Elems: []attr.Value{}}
+	for _, key := range keys {
+		ret.Elems = append(ret.Elems, types.Object{
+			AttrTypes: sdkKeyAttrTypes,
+			Attrs: map[string]attr.Value{
+				"key":         types.String{Value: key.Key},
+				"created_at":  types.String{Value: key.CreatedAt.UTC().Format(time.RFC3339)},
+				"compromised": types.Bool{Value: key.Compromised},
+				"invalidated": types.Bool{Value: key.Invalidated},
+			},
+		})
+	}
+	return ret
+}
+
+func sdkKeyConvert(keyLists ...[]sdkKey) types.List {
+	ret := types.List{ElemType: types.StringType, Elems: []attr.Value{}}
+	for _, keys := range keyLists {
+		for _, key := range keys {
+			ret.Elems = append(ret.Elems, types.String{Value: key.Key})
+		}
+	}
+	return ret
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestEnvironmentSDKKeys(t *testing.T) {
+	var env environment
+	err := json.Unmarshal([]byte(`{
+		"_id": "env-id",
+		"key": "development",
+		"sdkKeys": {
+			"server": [
+				{"key": "dvc_server_old", "createdAt": "2023-01-01T00:00:00Z", "compromised": true},
+				{"key": "dvc_server_new", "createdAt": "2023-02-01T00:00:00Z"},
+				{"key": "dvc_server_revoked", "createdAt": "2023-03-01T00:00:00Z", "invalidated": true}
+			],
+			"client": [{"key": "dvc_client", "createdAt": "2023-01-01T00:00:00Z"}],
+			"mobile": []
+		}
+	}`), &env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.Key != "development" || env.SdkKeys == nil || len(env.SdkKeys.Server) != 3 {
+		t.Fatalf("unexpected environment %+v", env)
+	}
+	if !env.SdkKeys.Server[0].Compromised || !env.SdkKeys.Server[2].Invalidated {
+		t.Errorf("expected key flags to be decoded, got %+v", env.SdkKeys.Server)
+	}
+
+	key, ok := usableSDKKey(env.SdkKeys.Server)
+	if !ok || key.Key != "dvc_server_new" {
+		t.Errorf("expected dvc_server_new to be usable, got %q", key.Key)
+	}
+
+	all, server, client, _ := environmentSDKKeys(env.SdkKeys, true)
+	if len(all.Elems) != 4 || len(server.Elems) != 3 || len(client.Elems) != 1 {
+		t.Errorf("unexpected keys %v %v %v", all, server, client)
+	}
+	compromised := server.Elems[0].(types.Object).Attrs["compromised"].(types.Bool)
+	if !compromised.Value {
+		t.Errorf("expected the first server key to be compromised")
+	}
+
+	all, server, _, _ = environmentSDKKeys(env.SdkKeys, false)
+	if !server.Null || len(all.Elems) != 1 || all.Elems[0].(types.String).Value != "dvc_client" {
+		t.Errorf("expected server keys to be left out, got %v %v", all, server)
+	}
+}
@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
+	dvc_server "github.com/devcyclehq/go-server-sdk/v2"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"math/rand"
 	"net/http"
 )
@@ -18,6 +21,24 @@ func randSeq(n int) string {
 	return string(b)
 }
 
+// optionalString maps an empty API value to null so that unset optional
+// attributes do not produce a diff.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.String{Null: true}
+	}
+	return types.String{Value: s}
+}
+
+// optionalBool keeps an unset optional attribute null while the API reports
+// its false default.
+func optionalBool(prior types.Bool, v bool) types.Bool {
+	if (prior.Null || prior.Unknown) && !v {
+		return types.Bool{Null: true}
+	}
+	return types.Bool{Value: v}
+}
+
 func userDataSchema() tfsdk.Attribute {
 	return tfsdk.Attribute{
 		MarkdownDescription: "User data to drive bucketing into variations for feature flag evaluations.",
@@ -48,6 +69,31 @@ func userDataSchema() tfsdk.Attribute {
 				Optional:            true,
 				Type:                types.StringType,
 			},
+			"country": {
+				MarkdownDescription: "User country, in ISO 3166 alpha-2 format",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"language": {
+				MarkdownDescription: "User language, in ISO 639-1 format",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"device_model": {
+				MarkdownDescription: "User device model",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"custom_data": {
+				MarkdownDescription: "User custom data, as a JSON object. Use `jsonencode` to keep number and boolean values typed.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
+			"private_custom_data": {
+				MarkdownDescription: "User private custom data, as a JSON object. Used for bucketing only and not logged to DevCycle.",
+				Optional:            true,
+				Type:                types.StringType,
+			},
 		}),
 		PlanModifiers: tfsdk.AttributePlanModifiers{
 			tfsdk.RequiresReplace(),
@@ -55,18 +101,79 @@ func userDataSchema() tfsdk.Attribute {
 	}
 }
 
-func handleDevCycleHTTP(err error, httpResponse *http.Response, resp *diag.Diagnostics) bool {
-	if err != nil || (httpResponse.StatusCode > 299 || httpResponse.StatusCode < 200) {
-		resp.AddError("Client Error", fmt.Sprintf("DevCycle Terraform Error: %s.\nHTTP Response: %v", err, httpResponse.Request))
-		return true
+// deletionProtectionAttribute returns the deletion_protection attribute of
+// resources that are checked with checkDeletionProtection before being deleted.
+func deletionProtectionAttribute(kind string) tfsdk.Attribute {
+	return tfsdk.Attribute{
+		MarkdownDescription: fmt.Sprintf("Whether Terraform is prevented from deleting the %s, including when it has to be replaced. Must be set to `false` and applied before the %s can be destroyed. Defaults to `false`.", kind, kind),
+		Optional:            true,
+		Type:                types.BoolType,
 	}
-	return false
+}
+
+// checkDeletionProtection adds an error and returns true if deletion protection
+// is enabled for the resource being deleted.
+func checkDeletionProtection(deletionProtection types.Bool, kind, key string, diags *diag.Diagnostics) bool {
+	if !deletionProtection.Value {
+		return false
+	}
+	diags.AddAttributeError(
+		tftypes.NewAttributePath().WithAttributeName("deletion_protection"),
+		"Deletion Protection Enabled",
+		fmt.Sprintf("The %s %q cannot be deleted while deletion_protection is enabled. Set deletion_protection to false and apply the change before destroying or replacing it.", kind, key),
+	)
+	return true
+}
+
+// handleDevCycleHTTP adds a diagnostic describing a failed management API
+// request, returning true if the request failed. authSource describes where the
+// provider credentials came from, and is named when they are rejected.
+func handleDevCycleHTTP(err error, httpResponse *http.Response, authSource string, resp *diag.Diagnostics) bool {
+	return handleDevCycleHTTPForSchema(err, httpResponse, authSource, tfsdk.Schema{}, resp)
 }
 
 type evaluatedVariableDataSourceDataUser struct {
-	Id         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	AppVersion types.String `tfsdk:"app_version"`
-	Email      types.String `tfsdk:"email"`
-	AppBuild   types.String `tfsdk:"app_build"`
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	AppVersion        types.String `tfsdk:"app_version"`
+	Email             types.String `tfsdk:"email"`
+	AppBuild          types.String `tfsdk:"app_build"`
+	Country           types.String `tfsdk:"country"`
+	Language          types.String `tfsdk:"language"`
+	DeviceModel       types.String `tfsdk:"device_model"`
+	CustomData        types.String `tfsdk:"custom_data"`
+	PrivateCustomData types.String `tfsdk:"private_custom_data"`
+}
+
+func (u evaluatedVariableDataSourceDataUser) toSDK() (dvc_server.DVCUser, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	user := dvc_server.DVCUser{
+		UserId:      u.Id.Value,
+		Name:        u.Name.Value,
+		Email:       u.Email.Value,
+		AppVersion:  u.AppVersion.Value,
+		AppBuild:    u.AppBuild.Value,
+		Country:     u.Country.Value,
+		Language:    u.Language.Value,
+		DeviceModel: u.DeviceModel.Value,
+	}
+	user.CustomData = userCustomDataToSDK(u.CustomData, "custom_data", &diags)
+	user.PrivateCustomData = userCustomDataToSDK(u.PrivateCustomData, "private_custom_data", &diags)
+	return user, diags
+}
+
+func userCustomDataToSDK(value types.String, attribute string, diags *diag.Diagnostics) map[string]interface{} {
+	if value.Null || value.Value == "" {
+		return nil
+	}
+	var customData map[string]interface{}
+	if err := json.Unmarshal([]byte(value.Value), &customData); err != nil {
+		diags.AddAttributeError(
+			tftypes.NewAttributePath().WithAttributeName("user").WithAttributeName(attribute),
+			"Invalid User Custom Data",
+			fmt.Sprintf("Custom data must be a JSON object, got error: %s", err),
+		)
+		return nil
+	}
+	return customData
 }
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// urlValidator validates that a string attribute is an absolute http(s) URL.
+type urlValidator struct{}
+
+func (v urlValidator) Description(ctx context.Context) string {
+	return "value must be an absolute http or https URL"
+}
+
+func (v urlValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an absolute `http` or `https` URL"
+}
+
+func (v urlValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+	if err := validateURL(value.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid URL", err.Error())
+	}
+}
+
+func validateURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid URL: %s", value, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q must be an absolute http or https URL", value)
+	}
+	return nil
+}
+
+// maxKeyLength is the longest key the management API accepts for projects,
+// environments, features, variables and other keyed objects.
+const maxKeyLength = 100
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9_.-]+$`)
+
+// keyValidator validates that a string attribute is a valid DevCycle key.
+type keyValidator struct{}
+
+func (v keyValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be at most %d lower-case letters, numbers, '_', '-' or '.'", maxKeyLength)
+}
+
+func (v keyValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("value must be at most %d lower-case letters, numbers, `_`, `-` or `.`", maxKeyLength)
+}
+
+func (v keyValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+	if err := validateKey(value.Value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Key", err.Error())
+	}
+}
+
+func validateKey(value string) error {
+	if value == "" || len(value) > maxKeyLength {
+		return fmt.Errorf("%q must be between 1 and %d characters long", value, maxKeyLength)
+	}
+	if !keyPattern.MatchString(value) {
+		return fmt.Errorf("%q must only contain lower-case letters, numbers, '_', '-' or '.'", value)
+	}
+	return nil
+}
+
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// hexColorValidator validates that a string attribute is a hex color with a
+// leading #, such as #1e90ff.
+type hexColorValidator struct{}
+
+func (v hexColorValidator) Description(ctx context.Context) string {
+	return "value must be a hex color with a leading #, such as #1e90ff"
+}
+
+func (v hexColorValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a hex color with a leading `#`, such as `#1e90ff`"
+}
+
+func (v hexColorValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+	if !hexColorPattern.MatchString(value.Value) {
+		resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Color", fmt.Sprintf("%q must be a hex color with a leading #, such as #1e90ff", value.Value))
+	}
+}
+
+// jsonValuesValidator validates that every value of a map attribute is a JSON
+// document.
+type jsonValuesValidator struct{}
+
+func (v jsonValuesValidator) Description(ctx context.Context) string {
+	return "values must be JSON encoded"
+}
+
+func (v jsonValuesValidator) MarkdownDescription(ctx context.Context) string {
+	return "values must be JSON encoded, for example with `jsonencode`"
+}
+
+func (v jsonValuesValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.Map)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+	for key, elem := range value.Elems {
+		elem, ok := elem.(types.String)
+		if !ok || elem.Null || elem.Unknown {
+			continue
+		}
+		if !json.Valid([]byte(elem.Value)) {
+			resp.Diagnostics.AddAttributeError(req.AttributePath.WithElementKeyString(key), "Invalid JSON", fmt.Sprintf("%q is not a JSON document. Encode the value with jsonencode.", elem.Value))
+		}
+	}
+}
+
+// Values accepted by the management API for enumerated attributes.
+var (
+	environmentTypeValues    = []string{"development", "staging", "production", "disaster_recovery"}
+	featureTypeValues        = []string{"release", "experiment", "permission", "ops"}
+	variableTypeValues       = []string{"String", "Boolean", "Number", "JSON"}
+	customPropertyTypeValues = []string{"String", "Number", "Boolean"}
+	sdkKeyTypeValues         = []string{"client", "server", "mobile"}
+)
+
+// oneOfValidator validates that a string attribute is one of a fixed set of
+// values.
+type oneOfValidator struct {
+	values []string
+}
+
+func oneOf(values ...string) oneOfValidator {
+	return oneOfValidator{values: values}
+}
+
+func (v oneOfValidator) Description(ctx context.Context) string {
+	return "value must be one of " + strings.Join(v.values, ", ")
+}
+
+func (v oneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be one of " + oneOfMarkdown(v.values)
+}
+
+func (v oneOfValidator) Validate(ctx context.Context, req tfsdk.ValidateAttributeRequest, resp *tfsdk.ValidateAttributeResponse) {
+	value, ok := req.AttributeConfig.(types.String)
+	if !ok || value.Null || value.Unknown {
+		return
+	}
+	for _, allowed := range v.values {
+		if value.Value == allowed {
+			return
+		}
+	}
+	resp.Diagnostics.AddAttributeError(req.AttributePath, "Invalid Value", fmt.Sprintf("%q must be one of %s.", value.Value, strings.Join(v.values, ", ")))
+}
+
+// oneOfMarkdown lists values for attribute descriptions, such as "`a`, `b` or
+// `c`".
+func oneOfMarkdown(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = "`" + value + "`"
+	}
+	if len(quoted) < 2 {
+		return strings.Join(quoted, "")
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
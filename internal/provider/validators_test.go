@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestStringValidators(t *testing.T) {
+	cases := []struct {
+		validator tfsdk.AttributeValidator
+		value     types.String
+		valid     bool
+	}{
+		{keyValidator{}, types.String{Value: "my-feature_key.v2"}, true},
+		{keyValidator{}, types.String{Value: "My Feature"}, false},
+		{keyValidator{}, types.String{Value: ""}, false},
+		{keyValidator{}, types.String{Value: string(make([]byte, maxKeyLength+1))}, false},
+		{keyValidator{}, types.String{Unknown: true}, true},
+		{hexColorValidator{}, types.String{Value: "#1E90ff"}, true},
+		{hexColorValidator{}, types.String{Value: "#fff"}, true},
+		{hexColorValidator{}, types.String{Value: "1e90ff"}, false},
+		{hexColorValidator{}, types.String{Value: "#1e90fg"}, false},
+		{oneOf(environmentTypeValues...), types.String{Value: "disaster_recovery"}, true},
+		{oneOf(environmentTypeValues...), types.String{Value: "prod"}, false},
+		{oneOf(variableTypeValues...), types.String{Value: "string"}, false},
+		{oneOf(variableTypeValues...), types.String{Null: true}, true},
+	}
+	for _, c := range cases {
+		resp := &tfsdk.ValidateAttributeResponse{}
+		c.validator.Validate(context.Background(), tfsdk.ValidateAttributeRequest{
+			AttributePath:   tftypes.NewAttributePath().WithAttributeName("test"),
+			AttributeConfig: c.value,
+		}, resp)
+		if resp.Diagnostics.HasError() == c.valid {
+			t.Errorf("%T %v: expected valid=%t, got %v", c.validator, c.value, c.valid, resp.Diagnostics)
+		}
+	}
+}
+
+func TestOneOfMarkdown(t *testing.T) {
+	if got := oneOfMarkdown(featureTypeValues); got != "`release`, `experiment`, `permission` or `ops`" {
+		t.Errorf("unexpected description %q", got)
+	}
+}
@@ -104,7 +104,7 @@ func (d variableDataSource) Read(ctx context.Context, req tfsdk.ReadDataSourceRe
 		return
 	}
 	variable, httpResponse, err := d.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, data.Key.Value, data.ProjectKey.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTP(err, httpResponse, d.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 	data.Id = types.String{Value: variable.Id}
@@ -2,12 +2,13 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	devcyclem "github.com/devcyclehq/go-mgmt-sdk"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
 )
 
 type variableResourceType struct{}
@@ -32,6 +33,7 @@ func (t variableResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 				MarkdownDescription: "Variable key",
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{keyValidator{}},
 			},
 			"feature_id": {
 				MarkdownDescription: "Feature that this variable is attached to",
@@ -50,9 +52,10 @@ func (t variableResourceType) GetSchema(ctx context.Context) (tfsdk.Schema, diag
 				},
 			},
 			"type": {
-				MarkdownDescription: "Variable datatype",
+				MarkdownDescription: "Variable datatype. One of " + oneOfMarkdown(variableTypeValues),
 				Required:            true,
 				Type:                types.StringType,
+				Validators:          []tfsdk.AttributeValidator{oneOf(variableTypeValues...)},
 				PlanModifiers: tfsdk.AttributePlanModifiers{
 					tfsdk.RequiresReplace(),
 				},
@@ -87,6 +90,16 @@ type variableResourceData struct {
 	Id          types.String `tfsdk:"id"`
 }
 
+func (d *variableResourceData) fromSDK(variable devcyclem.Variable) {
+	d.Id = types.String{Value: variable.Id}
+	d.Key = types.String{Value: variable.Key}
+	d.Name = types.String{Value: variable.Name}
+	d.Description = types.String{Value: variable.Description}
+	d.Type = types.String{Value: variable.Type_}
+	d.FeatureId = types.String{Value: variable.Feature}
+	d.ProjectId = types.String{Value: variable.Project}
+}
+
 type variableResource struct {
 	provider provider
 }
@@ -114,7 +127,7 @@ func (r variableResource) Create(ctx context.Context, req tfsdk.CreateResourceRe
 		Feature:     data.FeatureId.Value,
 		Type_:       data.Type.Value,
 	}, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 	data.Id = types.String{Value: variable.Id}
@@ -151,16 +164,15 @@ func (r variableResource) Read(ctx context.Context, req tfsdk.ReadResourceReques
 	}
 
 	variable, httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if isNotFound(httpResponse) {
+		tflog.Warn(ctx, "variable no longer exists in DevCycle, removing it from state", "project_id", data.ProjectId.Value, "key", data.Key.Value)
+		resp.State.RemoveResource(ctx)
 		return
 	}
-	data.Id = types.String{Value: variable.Id}
-	data.Key = types.String{Value: variable.Key}
-	data.Name = types.String{Value: variable.Name}
-	data.Description = types.String{Value: variable.Description}
-	data.Type = types.String{Value: variable.Type_}
-	data.FeatureId = types.String{Value: variable.Feature}
-	data.ProjectId = types.String{Value: variable.Project}
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+	data.fromSDK(variable)
 
 	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
@@ -187,7 +199,7 @@ func (r variableResource) Update(ctx context.Context, req tfsdk.UpdateResourceRe
 		Key:         data.Key.Value,
 		Feature:     data.FeatureId.Value,
 	}, data.Id.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTPForSchema(err, httpResponse, r.provider.AuthSource, req.Plan.Schema, &resp.Diagnostics); ret {
 		return
 	}
 	data.Id = types.String{Value: variable.Id}
@@ -219,7 +231,7 @@ func (r variableResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 	}
 
 	httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerRemove(ctx, data.Key.Value, data.ProjectId.Value)
-	if ret := handleDevCycleHTTP(err, httpResponse, &resp.Diagnostics); ret {
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
 		return
 	}
 
@@ -227,5 +239,21 @@ func (r variableResource) Delete(ctx context.Context, req tfsdk.DeleteResourceRe
 }
 
 func (r variableResource) ImportState(ctx context.Context, req tfsdk.ImportResourceStateRequest, resp *tfsdk.ImportResourceStateResponse) {
-	tfsdk.ResourceImportStatePassthroughID(ctx, tftypes.NewAttributePath().WithAttributeName("id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: project_key/variable_key. Got: %q", req.ID),
+		)
+		return
+	}
+
+	variable, httpResponse, err := r.provider.MgmtClient.VariablesApi.VariablesControllerFindOne(ctx, parts[1], parts[0])
+	if ret := handleDevCycleHTTP(err, httpResponse, r.provider.AuthSource, &resp.Diagnostics); ret {
+		return
+	}
+
+	var data variableResourceData
+	data.fromSDK(variable)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -18,6 +18,12 @@ func TestAccVariableResource(t *testing.T) {
 					resource.TestCheckResourceAttr("devcycle_variable.test", "key", testAccVariableResourceKey),
 				),
 			},
+			{
+				ResourceName:      "devcycle_variable.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectScopedImportStateId("devcycle_variable.test"),
+			},
 			{
 				Config:  testAccVariableResourceConfig,
 				Destroy: true,